@@ -0,0 +1,55 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const TimeEntryActivitiesEndpoint = "/enumerations/" + EnumTimeEntryActivities + ".json"
+
+var ActivityNotFoundError = errors.New("time entry activity not found")
+
+// A Redmine time entry activity, e.g. Design, Development.
+type TimeEntryActivity = Enumeration
+
+// Get the global list of time entry activities.
+func (ac *ApiClient) GetTimeEntryActivities() ([]TimeEntryActivity, error) {
+	return ac.GetEnumeration(EnumTimeEntryActivities)
+}
+
+// Get the time entry activities of project, they may be overridden per project,
+// so the list may differ from the global one.
+func (ac *ApiClient) GetProjectTimeEntryActivities(projectID int) ([]TimeEntryActivity, error) {
+	p, err := ac.GetProject(projectID, "time_entry_activities")
+	if err != nil {
+		return nil, err
+	}
+	return p.TimeEntryActivities, nil
+}
+
+// Resolve the activity name (case insensitive) to activity of the project, empty name means
+// the default activity. The project activities are preferred over the global list,
+// which is used only if the project has no activities. This prevents the
+// "activity is not valid for this project" error on time entry creation.
+func (ac *ApiClient) ResolveActivity(projectID int, name string) (*TimeEntryActivity, error) {
+	activities, err := ac.GetProjectTimeEntryActivities(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(activities) == 0 {
+		if activities, err = ac.GetTimeEntryActivities(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range activities {
+		if !a.Active {
+			continue
+		}
+		if (name == "" && bool(a.IsDefault)) || (name != "" && strings.EqualFold(a.Name, name)) {
+			return &a, nil
+		}
+	}
+	return nil, errors.Join(ActivityNotFoundError, fmt.Errorf("project %d: %q", projectID, name))
+}
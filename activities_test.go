@@ -0,0 +1,56 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveActivity(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TimeEntryActivitiesEndpoint:
+			w.Write([]byte(`{"time_entry_activities": [
+				{"id": 8, "name": "Design", "is_default": true, "active": true},
+				{"id": 9, "name": "Development", "active": true}]}`))
+		case "/projects/1.json":
+			w.Write([]byte(`{"project": {"id": 1, "time_entry_activities": [
+				{"id": 9, "name": "Development", "active": false},
+				{"id": 10, "name": "Support", "is_default": true, "active": true}]}}`))
+		case "/projects/2.json":
+			w.Write([]byte(`{"project": {"id": 2}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	cases := []struct {
+		projectID int
+		name      string
+		expected  int
+	}{
+		{1, "", 10},
+		{1, "support", 10},
+		{2, "", 8},
+		{2, "Development", 9},
+	}
+	for _, c := range cases {
+		a, err := ac.ResolveActivity(c.projectID, c.name)
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %s", c, err)
+			continue
+		}
+		if a.Id != c.expected {
+			t.Errorf("%+v: expected activity %d, got: %d", c, c.expected, a.Id)
+		}
+	}
+
+	// inactive in project
+	if _, err := ac.ResolveActivity(1, "Development"); !errors.Is(err, ActivityNotFoundError) {
+		t.Errorf("expected ActivityNotFoundError, got: %s", err)
+	}
+}
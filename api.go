@@ -6,14 +6,20 @@ package redmine
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,6 +27,7 @@ const (
 	ProjectsApiEndpoint = "/projects.json"
 	IssuesApiEndpoint   = "/issues.json"
 	TimeEntriesEndpoint = "/time_entries.json"
+	UsersApiEndpoint    = "/users.json"
 )
 
 // Time Entries filtration by range of dates and user id.
@@ -30,45 +37,172 @@ type TimeEntriesFilter struct {
 	UserId    string
 }
 
-// Config of Redmine REST API client: url, token, logging and time entries filtration.
-type ApiConfig struct {
+// The upper bound of issue ids in one request, longer lists are split into batches.
+const MaxIssueIDs = 100
+
+// The max number of items per page allowed by Redmine.
+const MaxLimit = 100
+
+// Redmine REST API client: url, token, logging and time entries filtration.
+type ApiClient struct {
 	Url        string
 	Token      string
 	LogEnabled bool
 	TimeEntriesFilter
+	IssuesFilter
+	Retry RetryPolicy
+	// Associated data included to every request of resource by default, keyed by
+	// resource name: "issues", "projects", e.g. {"issues": {"journals", "attachments"}}.
+	DefaultIncludes map[string][]string
+	// HTTP client used for requests, e.g. with custom transport or timeout, nil means
+	// [DefaultHTTPClient].
+	HTTPClient *http.Client
+	// Login of user to impersonate (X-Redmine-Switch-User header), requires admin token.
+	SwitchUser string
+	Decode     DecodeOptions
+	// Logger of requests and scroll progress (if LogEnabled), nil means the standard logger.
+	Logger Logger
+	// Metrics hook of requests and errors, nil means no-op.
+	Metrics Metrics
+	// Query params of pagination, zero value means the standard Redmine ones.
+	PageParams PageParams
+	// Number of items per page, zero means the default of server (25), it is capped
+	// at [MaxLimit], fewer larger pages need fewer round trips.
+	Limit int
+	// Header of request (correlation) id for tracing, e.g. X-Request-Id, empty means no header.
+	RequestIDHeader string
+	// Generator of request ids, nil means a random id per request.
+	RequestID func() string
+	// Version of Redmine server, e.g. "5.1.2", to branch the feature-gated code paths
+	// without probing, see [ApiClient.ServerVersion].
+	ServerVersionHint string
+	// Max number of requests in flight at once across all goroutines sharing the client,
+	// zero means no limit. It is read once, on the first request.
+	MaxConcurrent int
+	// Min interval between starts of requests across all goroutines sharing the client,
+	// e.g. to not exceed the per-key throttling of shared server, zero means no limit.
+	MinInterval time.Duration
+
+	state   *clientState    // shared with copies, see throttle.go
+	version *RedmineVersion // detected server version, guarded by versionMu
+}
+
+// Logger interface, satisfied by [log.Logger].
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// Log the message if logging is enabled.
+func (ac *ApiClient) logf(format string, v ...any) {
+	if !ac.LogEnabled {
+		return
+	}
+	if ac.Logger != nil {
+		ac.Logger.Printf(format, v...)
+		return
+	}
+	log.Printf(format, v...)
+}
+
+// Config of Redmine REST API client.
+//
+// Deprecated: use [ApiClient] instead, this alias is kept for backward compatibility.
+type ApiConfig = ApiClient
+
+// Create a new Redmine REST API client.
+func CreateApiClient(url, token string, logEnabled bool, f TimeEntriesFilter) *ApiClient {
+	return &ApiClient{Url: url, Token: token, LogEnabled: logEnabled, TimeEntriesFilter: f, Retry: DefaultRetryPolicy}
+}
+
+// A reference to another Redmine entity, e.g. tracker, status or parent project.
+type NamedRef struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 // A Redmine issue entity.
 type Issue struct {
-	Id      int    `json:"id"`
-	Subject string `json:"subject"`
-	Desc    string `json:"description"`
-	Project `json:"project"`
+	Id         int    `json:"id"`
+	Subject    string `json:"subject"`
+	Desc       string `json:"description"`
+	Project    `json:"project"`
+	Status     NamedRef  `json:"status"`
+	Tracker    NamedRef  `json:"tracker"`
+	Priority   NamedRef  `json:"priority"`
+	Category   *NamedRef `json:"category,omitempty"` // nil if not set
+	IsPrivate  FlexBool  `json:"is_private"`
+	AssignedTo NamedRef  `json:"assigned_to"` // zero if issue is not assigned
+	DoneRatio  int       `json:"done_ratio"`
+	Parent     *NamedRef `json:"parent,omitempty"` // nil for top-level issues, only id is set
+	StartDate  Date      `json:"start_date"`       // zero if not set
+	DueDate    Date      `json:"due_date"`         // zero if not set
+	// Target version of issue, nil if not set.
+	FixedVersion   *NamedRef `json:"fixed_version,omitempty"`
+	EstimatedHours float32   `json:"estimated_hours"` // zero if not estimated
+	// Logged hours of issue and of issue with subtasks, returned by recent Redmine versions
+	// (sometimes only with include=spent_time), zero if absent.
+	SpentHours      float32 `json:"spent_hours"`
+	TotalSpentHours float32 `json:"total_spent_hours"`
+	// Associated data of issue, present only if requested with include=journals,
+	// include=attachments, include=relations or include=watchers respectively.
+	Journals    []Journal    `json:"journals,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	Relations   []Relation   `json:"relations,omitempty"`
+	Watchers    []NamedRef   `json:"watchers,omitempty"`
+	// Values of custom fields enabled for the tracker and project of issue.
+	CustomFields []CustomField `json:"custom_fields,omitempty"`
 }
 
 // A Redmine project entity.
 type Project struct {
-	Id    int    `json:"id"`
-	Name  string `json:"name"`
-	Ident string `json:"identifier"`
-	Desc  string `json:"description"`
-	// TODO correct parsing date time
-	// CreatedOn time.Time `json:"created_on"`
-	// UpdatedOn time.Time `json:"updated_on"`
-	IsPublic bool `json:"is_public"`
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	Ident     string    `json:"identifier"`
+	Desc      string    `json:"description"`
+	CreatedOn DateTime  `json:"created_on"`
+	UpdatedOn DateTime  `json:"updated_on"`
+	IsPublic  FlexBool  `json:"is_public"`
+	Parent    *NamedRef `json:"parent,omitempty"` // nil for top-level projects
+	// Trackers enabled for the project, present only if requested with include=trackers.
+	Trackers []NamedRef `json:"trackers,omitempty"`
+	// Time entry activities of the project, present only if requested with
+	// include=time_entry_activities.
+	TimeEntryActivities []TimeEntryActivity `json:"time_entry_activities,omitempty"`
 }
 
 // A Redmine user entity.
 type User struct {
-	Id   int    `json:"id"`
-	Name string `json:"name"`
+	Id        int    `json:"id"`
+	Name      string `json:"name"` // set only in references to user, e.g. author of time entry
+	Login     string `json:"login"`
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	Mail      string `json:"mail"`   // visible only to admins or if the user allows it
+	Status    int    `json:"status"` // see [UserStatusActive] etc., set only for admins
+	// Project memberships of user, present only if requested with include=memberships.
+	Memberships []Membership `json:"memberships,omitempty"`
 }
 
 // A date type is needed for proper parsing (unmarshaling) of redmine date format used in JSON.
+//
+// Redmine dates (yyyy-mm-dd) have no timezone, so a date is the calendar day of its own
+// wall clock: e.g. Date{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)} is 2024-03-01 on any
+// machine. The dates parsed from strings are UTC midnights, so they are formatted back
+// to the same date regardless of timezone. Use [NewDateIn] or [TodayIn] to take the date
+// of time in a location other than its own, e.g. for SpentOn of time entry.
 type Date struct {
 	time.Time
 }
 
+// Create a date from redmine date string (yyyy-mm-dd), see [Date].
+func DateFromString(s string) (Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
 // There are some custom error types, from low level to high level errors
 // which are aggregates of first ones.
 //
@@ -81,37 +215,78 @@ type Date struct {
 //   - [ApiNewRequestFatalError]: actually will not be thrown (see the comments in code)
 var (
 	JsonDecodeError          = errors.New("JSON decode error")
+	JsonEncodeError          = errors.New("JSON encode error")
 	IoReadError              = errors.New("io.ReadAll error")
+	IoWriteError             = errors.New("io write error")
 	UrlJoinPathError         = errors.New("url.JoinPath error")
 	UrlParseError            = errors.New("url.Parse error")
 	ApiEndpointUrlFatalError = errors.New("cannot build API endpoint url")
 	ApiNewRequestFatalError  = errors.New("cannot create a new request with given url")
 	HttpError                = errors.New("http error")
+	NotFoundError            = errors.New("not found")
+	PageOutOfRangeError      = errors.New("page is out of range")
+	AuthError                = errors.New("authentication or authorization failed: check the API key")
+	ApiDisabledError         = errors.New("REST API seems to be disabled on the server: " +
+		"enable REST web service in Administration → Settings → API")
+	RateLimitedError = errors.New("too many requests: rate limited by server, see RetryAfter")
+	// The last error of scroll which is not retried (e.g. 404) or whose retries are exhausted,
+	// the scroll is stopped after it.
+	ScrollStoppedError = errors.New("scroll stopped: the error is not retried")
+	// The same as [RateLimitedError].
+	RateLimitError = RateLimitedError
 )
 
 // Unmarshaling redmine dates.
 func (d *Date) UnmarshalJSON(b []byte) error {
-	t, err := time.Parse("2006-01-02", string(bytes.Trim(b, "\"")))
+	if string(b) == "null" {
+		*d = Date{}
+		return nil
+	}
+	t, err := DateFromString(string(bytes.Trim(b, "\"")))
 	if err != nil {
 		return errors.Join(JsonDecodeError, err)
 	}
-	d.Time = t
+	*d = t
 	return nil
 }
 
+// Boolean decoded leniently: besides true/false it accepts 0/1, "0"/"1", "true"/"false",
+// as some Redmine and proxy setups serialize booleans oddly, null is false.
+type FlexBool bool
+
+func (b *FlexBool) UnmarshalJSON(data []byte) error {
+	switch string(bytes.Trim(data, "\"")) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "", "null":
+		*b = false
+	default:
+		return errors.Join(JsonDecodeError, fmt.Errorf("invalid boolean: %s", data))
+	}
+	return nil
+}
+
+// Marshaling redmine dates.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
 func (d Date) String() string {
 	return d.Time.Format("2006-01-02")
 }
 
 // A Redmine time entries.
 type TimeEntry struct {
-	Id      int `json:"id"`
-	Project `json:"project"`
-	Issue   `json:"issue"`
-	User    `json:"user"`
-	Hours   float32 `json:"hours"`
-	Comment string  `json:"comments"`
-	SpentOn Date    `json:"spent_on"`
+	Id       int `json:"id"`
+	Project  `json:"project"`
+	Issue    `json:"issue"`
+	User     `json:"user"`
+	Activity NamedRef `json:"activity"`
+	Hours    float32  `json:"hours"`
+	// The raw hours with full precision, set only if [DecodeOptions.UseNumber] is enabled.
+	RawHours json.Number `json:"-"`
+	Comment  string      `json:"comments"`
+	SpentOn  Date        `json:"spent_on"`
 }
 
 type Pagination struct {
@@ -122,7 +297,7 @@ type Pagination struct {
 
 func (t TimeEntry) String() string {
 	return fmt.Sprintf(
-		"%-5d %5.2f %s %-15s %s", t.Issue.Id, t.Hours, t.SpentOn, t.User.Name, t.Comment)
+		"%-5d %5.2f %s %-15s %s", t.Issue.Id, t.Hours, t.SpentOn, t.User.FullName(), t.Comment)
 }
 
 func (i Issue) String() string {
@@ -132,17 +307,62 @@ func (i Issue) String() string {
 // Data type constraint, a quick glance at which will let you know the supported data types
 // for fetching from redmine server.
 type Entities interface {
-	Project | Issue | TimeEntry
+	Project | Issue | TimeEntry | User
 }
 
 // Redmine API items response container.
-type ApiResponse[E Entities] struct {
+type ApiResponse[E any] struct {
 	Items []E
 	Pagination
+
+	// Errors of malformed items skipped in lenient mode, see [DecodeOptions].
+	DecodeErrors []error `json:"-"`
+}
+
+// Compute the offset of the page following the one fetched at the given offset.
+//
+// Some minimal or plugin endpoints omit pagination metadata entirely (no offset, limit and
+// total_count), in that case the pages are fetched until an empty one is returned.
+func (r ApiResponse[E]) next(p Paginator, offset int) int {
+	if r.Limit == 0 && r.Total == 0 && r.size() > 0 {
+		return offset + r.size()
+	}
+	// the next offset is derived from the offset&limit the server actually applied (it
+	// may clamp the limit), but it must move forward to not fetch the same page forever,
+	// e.g. of gateway ignoring the offset param, and stop at the total count
+	next := p.Next(r.Pagination)
+	if next >= 0 && next <= offset {
+		next = offset + r.size()
+		if r.Total > 0 && next >= r.Total {
+			return -1
+		}
+	}
+	return next
+}
+
+// The number of items of page including the skipped malformed ones.
+func (r ApiResponse[E]) size() int {
+	return len(r.Items) + len(r.DecodeErrors)
+}
+
+// Options of decoding of Redmine API responses.
+type DecodeOptions struct {
+	// Decode numbers as [json.Number] instead of float64 into fields of interface type
+	// and keep the raw string of time entry hours in [TimeEntry.RawHours],
+	// e.g. for financial reconciliation with controlled rounding.
+	UseNumber bool
+	// Decode items of page one by one: the malformed items are skipped and reported
+	// in [ApiResponse.DecodeErrors] instead of failing the whole page.
+	Lenient bool
 }
 
 // Decode JSON Redmine API response to package types.
 func DecodeResp[E Entities](body io.ReadCloser) (*ApiResponse[E], error) {
+	return DecodeRespWith[E](body, DecodeOptions{})
+}
+
+// Decode JSON Redmine API response to package types with the given options.
+func DecodeRespWith[E Entities](body io.ReadCloser, opts DecodeOptions) (*ApiResponse[E], error) {
 	defer body.Close()
 	apiResp := ApiResponse[E]{}
 
@@ -163,11 +383,36 @@ func DecodeResp[E Entities](body io.ReadCloser) (*ApiResponse[E], error) {
 		b = bytes.Replace(data, []byte("issues"), []byte("Items"), 1)
 	case TimeEntry:
 		b = bytes.Replace(data, []byte("time_entries"), []byte("Items"), 1)
+	case User:
+		b = bytes.Replace(data, []byte("users"), []byte("Items"), 1)
+	}
+	if opts.Lenient {
+		return decodeLenient[E](b, opts)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if opts.UseNumber {
+		dec.UseNumber()
 	}
-	if err = json.Unmarshal(b, &apiResp); err != nil {
+	if err = dec.Decode(&apiResp); err != nil {
 		return nil, errors.Join(JsonDecodeError, err)
 	}
 
+	if entries, ok := any(apiResp.Items).([]TimeEntry); ok && opts.UseNumber {
+		// the second pass to keep the raw hours, entries share the backing array with items
+		var raw struct {
+			Items []struct {
+				Hours json.Number `json:"hours"`
+			}
+		}
+		if err = json.Unmarshal(b, &raw); err != nil {
+			return nil, errors.Join(JsonDecodeError, err)
+		}
+		for i := range min(len(entries), len(raw.Items)) {
+			entries[i].RawHours = raw.Items[i].Hours
+		}
+	}
+
 	// TODO find a way to make generic struct tag for simplify code:
 	// if err := json.NewDecoder(body).Decode(&apiResp); err != nil {
 	// 	return nil, err
@@ -177,6 +422,48 @@ func DecodeResp[E Entities](body io.ReadCloser) (*ApiResponse[E], error) {
 
 }
 
+// Decode items of response one by one, collecting the errors of malformed ones.
+func decodeLenient[E Entities](b []byte, opts DecodeOptions) (*ApiResponse[E], error) {
+	var raw struct {
+		Items []json.RawMessage
+		Pagination
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, errors.Join(JsonDecodeError, err)
+	}
+
+	apiResp := ApiResponse[E]{Pagination: raw.Pagination}
+	for i, item := range raw.Items {
+		var v E
+		dec := json.NewDecoder(bytes.NewReader(item))
+		if opts.UseNumber {
+			dec.UseNumber()
+		}
+		if err := dec.Decode(&v); err != nil {
+			apiResp.DecodeErrors = append(apiResp.DecodeErrors,
+				errors.Join(JsonDecodeError, fmt.Errorf("item %d: %w", i, err)))
+			continue
+		}
+		if t, ok := any(&v).(*TimeEntry); ok && opts.UseNumber {
+			var h struct {
+				Hours json.Number `json:"hours"`
+			}
+			if err := json.Unmarshal(item, &h); err == nil {
+				t.RawHours = h.Hours
+			}
+		}
+		apiResp.Items = append(apiResp.Items, v)
+	}
+	return &apiResp, nil
+}
+
+// Set limit query param unless it is set already, e.g. by [Count].
+func (ac *ApiClient) setLimit(v *url.Values) {
+	if ac.Limit > 0 && !v.Has("limit") {
+		v.Set("limit", strconv.Itoa(min(ac.Limit, MaxLimit)))
+	}
+}
+
 // Add pagination query string to URL.
 func BuildApiUrl(base, endpoint string, v *url.Values, p int) (string, error) {
 	uri, err := url.JoinPath(base, endpoint)
@@ -201,14 +488,30 @@ func BuildApiUrl(base, endpoint string, v *url.Values, p int) (string, error) {
 }
 
 // Construct the final URL for http requests depending on redmine entities
-// (projects, issues or time entries) and pagination, filtration.
+// (projects, issues, time entries or users) and pagination, filtration.
 func ApiEndpointURL[E Entities](ac *ApiConfig, page int) (u string, err error) {
+	return apiEndpointURL[E](ac, url.Values{}, page)
+}
+
+// Construct the URL for http requests starting from the given offset instead of page number.
+func ApiEndpointOffsetURL[E Entities](ac *ApiConfig, offset int) (u string, err error) {
 	v := url.Values{}
+	ac.PageParams.setOffset(&v, offset)
+	return apiEndpointURL[E](ac, v, 0)
+}
+
+func apiEndpointURL[E Entities](ac *ApiConfig, v url.Values, page int) (u string, err error) {
+	ac.setLimit(&v)
+	ac.PageParams.setPage(&v, page)
+	page = 0 // already set with the configured param name
 	e := new(E)
 	switch any(*e).(type) {
 	case Project:
+		setInclude(&v, ac.includes("projects"))
 		u, err = BuildApiUrl(ac.Url, ProjectsApiEndpoint, &v, page)
 	case Issue:
+		setInclude(&v, ac.includes("issues", ac.IssuesFilter.Include...))
+		ac.IssuesFilter.encode(&v)
 		u, err = BuildApiUrl(ac.Url, IssuesApiEndpoint, &v, page)
 	case TimeEntry:
 		// filter by user and dates: get the time entries of user for a month
@@ -216,96 +519,621 @@ func ApiEndpointURL[E Entities](ac *ApiConfig, page int) (u string, err error) {
 		v.Set("from", ac.StartDate.Format("2006-01-02"))
 		v.Set("to", ac.EndDate.Format("2006-01-02"))
 		u, err = BuildApiUrl(ac.Url, TimeEntriesEndpoint, &v, page)
+	case User:
+		u, err = BuildApiUrl(ac.Url, UsersApiEndpoint, &v, page)
 	}
 	return
 }
 
-// Get Redmine entities respecting the setted filtration (time entries) and page of pagination.
-func Get[E Entities](ac *ApiConfig, page int) (*ApiResponse[E], error) {
-	http_cli := http.Client{}
+// Send http request to Redmine API: set the auth headers and log request and response
+// status if logging is enabled.
+func (ac *ApiClient) do(method, uri string, body io.Reader) (*http.Response, error) {
+	return ac.doContext(context.Background(), method, uri, "application/json", body)
+}
 
-	api_endpoint_url, err := ApiEndpointURL[E](ac, page)
+// Send http request to Redmine API like do, but with body of the given content type.
+func (ac *ApiClient) doContent(method, uri, contentType string, body io.Reader) (*http.Response, error) {
+	return ac.doContext(context.Background(), method, uri, contentType, body)
+}
+
+// Send http request to Redmine API like doContent, canceled along with ctx.
+func (ac *ApiClient) doContext(ctx context.Context, method, uri, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := ac.newRequest(ctx, method, uri, body)
 	if err != nil {
-		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
 	}
+	return ac.send(req)
+}
 
-	req, err := http.NewRequest("GET", api_endpoint_url, nil)
+// Create http request to Redmine API with the auth headers.
+func (ac *ApiClient) newRequest(ctx context.Context, method, uri string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
 	if err != nil {
 		// actually this block is never be run cos the url already passed the validation
-		// in ApiEndpointURL function,
+		// in url builder functions,
 		// method is correct and hardcoded, there are no other cases when the
 		// NewRequest will failed (check the source code)
 		return nil, errors.Join(ApiNewRequestFatalError, err)
 	}
 	req.Header.Add("User-Agent", "redmine go client v0.1")
 	req.Header.Add("X-Redmine-API-Key", ac.Token)
-	if ac.LogEnabled {
-		log.Printf("> %s %s", req.Method, req.URL)
+	if ac.SwitchUser != "" {
+		req.Header.Add("X-Redmine-Switch-User", ac.SwitchUser)
+	}
+	if ac.RequestIDHeader != "" {
+		gen := ac.RequestID
+		if gen == nil {
+			gen = randomRequestID
+		}
+		req.Header.Set(ac.RequestIDHeader, gen())
 	}
+	return req, nil
+}
+
+// Generate a random request id: 16 bytes in hex.
+func randomRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// The timeout of [DefaultHTTPClient], it covers the whole exchange including reading
+// of response body, so the large downloads may need a client with longer timeout.
+const DefaultTimeout = 60 * time.Second
+
+// HTTP client shared by all the clients without [ApiClient.HTTPClient], so the connections
+// are reused between requests.
+var DefaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+// Send http request, log request and response status if logging is enabled.
+func (ac *ApiClient) send(req *http.Request) (*http.Response, error) {
+	http_cli := ac.HTTPClient
+	if http_cli == nil {
+		http_cli = DefaultHTTPClient
+	}
+
+	if ac.RequestIDHeader != "" {
+		ac.logf("> %s %s (%s: %s)", req.Method, req.URL, ac.RequestIDHeader, req.Header.Get(ac.RequestIDHeader))
+	} else {
+		ac.logf("> %s %s", req.Method, req.URL)
+	}
+	if err := ac.pace(req.Context()); err != nil {
+		return nil, errors.Join(HttpError, err)
+	}
+	release, err := ac.acquire(req.Context())
+	if err != nil {
+		return nil, errors.Join(HttpError, err)
+	}
+	ac.countRequest()
+	start := time.Now()
 	res, err := http_cli.Do(req)
 	if err != nil {
+		release()
+		ac.metrics().ObserveRequest(req.Method, 0, time.Since(start))
+		ac.metrics().ObserveError(ErrorKindTransport)
 		return nil, errors.Join(HttpError, err)
 	}
-	if ac.LogEnabled {
-		log.Printf("< %s", res.Status)
+	ac.metrics().ObserveRequest(req.Method, res.StatusCode, time.Since(start))
+	if res.StatusCode >= 400 {
+		ac.metrics().ObserveError(ErrorKindStatus)
 	}
+	ac.logf("< %s", res.Status)
+	ac.setLastResponse(res)
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+	return res, nil
+}
 
-	return DecodeResp[E](res.Body)
+// Status code and headers of response, e.g. X-RateLimit-*, ETag.
+type ResponseInfo struct {
+	StatusCode int
+	Header     http.Header
+}
+
+func (ac *ApiClient) setLastResponse(res *http.Response) {
+	st := ac.shared()
+	st.lastMu.Lock()
+	defer st.lastMu.Unlock()
+	st.last = ResponseInfo{res.StatusCode, res.Header.Clone()}
+}
+
+// Get the status and headers of the last response received by client, e.g. for debugging
+// of throttling and caching, zero if there were no responses yet. If the client is shared
+// by goroutines, it is the response of whichever request completed last.
+func (ac *ApiClient) LastResponse() ResponseInfo {
+	st := ac.shared()
+	st.lastMu.Lock()
+	defer st.lastMu.Unlock()
+	return st.last
+}
+
+// Check whether the error is fatal: retrying the request will not help, e.g. malformed URL,
+// wrong API key, disabled REST API, or the scroll is stopped after it ([ScrollStoppedError]).
+// The rest errors, e.g. transient network failures or 5xx statuses, may go away on retry.
+func IsFatal(err error) bool {
+	for _, fatal := range []error{ApiEndpointUrlFatalError, ApiNewRequestFatalError, AuthError, ApiDisabledError,
+		ScrollStoppedError} {
+		if errors.Is(err, fatal) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check the status code of response, anything except 2xx is treated as [HttpError],
+// 401 and 403 additionally are [AuthError], 404 is [NotFoundError].
+func checkStatus(res *http.Response) error {
+	switch {
+	case apiDisabled(res):
+		return apiDisabledError(res)
+	case isAuthFailure(res):
+		return authError(res)
+	case res.StatusCode == http.StatusTooManyRequests:
+		return rateLimitedError(res)
+	case res.StatusCode == http.StatusNotFound:
+		return errors.Join(HttpError, NotFoundError, fmt.Errorf("%s %s", res.Request.URL, res.Status))
+	case res.StatusCode < 200 || res.StatusCode > 299:
+		return errors.Join(HttpError, newStatusError(res))
+	}
+	return nil
+}
+
+// Detect the common misconfiguration: the REST API is switched off in the settings of Redmine.
+// The server then redirects API requests to the login page or responds with HTML instead
+// of JSON, or with 403/422 mentioning the disabled API.
+func apiDisabled(res *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if mediaType == "text/html" && res.StatusCode/100 == 2 && res.Request != nil {
+		p := res.Request.URL.Path
+		if strings.HasSuffix(p, ".json") || strings.HasSuffix(p, "/login") {
+			return true
+		}
+	}
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	// peek at the body and put it back for the further error reporting
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), res.Body), res.Body}
+	text := strings.ToLower(string(data))
+	return (strings.Contains(text, "api") || strings.Contains(text, "rest web service")) &&
+		(strings.Contains(text, "disabled") || strings.Contains(text, "not enabled"))
+}
+
+func isAuthFailure(res *http.Response) bool {
+	return res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden
+}
+
+func authError(res *http.Response) error {
+	return errors.Join(HttpError, AuthError, newStatusError(res))
+}
+
+// Check the response of list request: the failure responses can't be decoded, so anything
+// except 2xx is [HttpError] carrying the status and the errors reported by Redmine
+// (or the raw body), see [responseError].
+func checkPage(res *http.Response) error {
+	if apiDisabled(res) || res.StatusCode < 200 || res.StatusCode > 299 {
+		return responseError(res)
+	}
+	return nil
+}
+
+func apiDisabledError(res *http.Response) error {
+	return errors.Join(HttpError, ApiDisabledError, fmt.Errorf("unexpected response: %s", res.Status))
+}
+
+// Merge the default includes of resource with the per-call ones, skipping duplicates.
+func (ac *ApiClient) includes(resource string, include ...string) (res []string) {
+	for _, i := range append(slices.Clone(ac.DefaultIncludes[resource]), include...) {
+		if !slices.Contains(res, i) {
+			res = append(res, i)
+		}
+	}
+	return
+}
+
+// Set include query param: comma separated list of associated data.
+func setInclude(v *url.Values, include []string) {
+	if len(include) > 0 {
+		v.Set("include", strings.Join(include, ","))
+	}
+}
+
+// Get Redmine entities respecting the setted filtration (time entries) and page of pagination.
+func Get[E Entities](ac *ApiConfig, page int) (*ApiResponse[E], error) {
+	if batches := issueIDBatches[E](ac); batches != nil {
+		first, err := Get[E](batches[0], page)
+		if err != nil {
+			return nil, err
+		}
+		return mergeBatches(context.Background(), batches, first)
+	}
+
+	api_endpoint_url, err := ApiEndpointURL[E](ac, page)
+	if err != nil {
+		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+
+	res, err := ac.do(http.MethodGet, api_endpoint_url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkPage(res); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
+	r, err := DecodeRespWith[E](res.Body, ac.Decode)
+	ac.observeDecode(err)
+	return r, err
+}
+
+// Get the page of Redmine entities like [Get], but for the page beyond the last one
+// (Redmine returns an empty list for it) return [PageOutOfRangeError] along with
+// the response, e.g. to disable "next" button of pagination UI.
+func GetPage[E Entities](ac *ApiClient, page int) (*ApiResponse[E], error) {
+	r, err := Get[E](ac, page)
+	if err != nil {
+		return nil, err
+	}
+	if page > 1 && r.Offset >= r.Total {
+		return r, errors.Join(PageOutOfRangeError, fmt.Errorf("page %d, total count %d", page, r.Total))
+	}
+	return r, nil
+}
+
+// Send GET request to the URL of Redmine API canceled along with ctx, e.g. on Ctrl-C
+// or timeout of parent request. Non-2xx status is returned as error (see [HttpError]),
+// the caller is responsible for closing of body of response.
+func (ac *ApiClient) GetWithContext(ctx context.Context, uri string) (*http.Response, error) {
+	res, err := ac.doContext(ctx, http.MethodGet, uri, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkStatus(res); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+	return res, nil
+}
+
+// Get Redmine entities starting from the given offset, see [Get].
+func GetOffset[E Entities](ac *ApiConfig, offset int) (*ApiResponse[E], error) {
+	return getOffset[E](context.Background(), ac, offset)
+}
+
+// Get Redmine entities starting from the given offset, canceled along with ctx.
+func getOffset[E Entities](ctx context.Context, ac *ApiClient, offset int) (*ApiResponse[E], error) {
+	if batches := issueIDBatches[E](ac); batches != nil {
+		first, err := getOffset[E](ctx, batches[0], offset)
+		if err != nil {
+			return nil, err
+		}
+		return mergeBatches(ctx, batches, first)
+	}
+
+	api_endpoint_url, err := ApiEndpointOffsetURL[E](ac, offset)
+	if err != nil {
+		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+
+	res, err := ac.doContext(ctx, http.MethodGet, api_endpoint_url, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkPage(res); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
+	r, err := DecodeRespWith[E](res.Body, ac.Decode)
+	ac.observeDecode(err)
+	return r, err
+}
+
+// Get a single Redmine entity, the single-resource response wraps the entity
+// under singular key unlike the list one, e.g. {"issue": {...}} of /issues/156.json,
+// so the key must be given. Useful for the endpoints not covered by the package,
+// e.g. of plugins, 404 is [NotFoundError].
+func GetOne[T any](ac *ApiClient, uri, key string) (_ *T, err error) {
+	defer func() { ac.observeDecode(err) }()
+
+	res, err := ac.do(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if err = checkStatus(res); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Join(IoReadError, err)
+	}
+
+	// decode only the entity key, there may be other keys like total_count
+	var envelope map[string]json.RawMessage
+	if err = json.Unmarshal(data, &envelope); err != nil {
+		return nil, errors.Join(JsonDecodeError, err)
+	}
+	raw, ok := envelope[key]
+	if !ok {
+		return nil, errors.Join(JsonDecodeError, fmt.Errorf("key %q not found in response", key))
+	}
+	var v T
+	if err = json.Unmarshal(raw, &v); err != nil {
+		return nil, errors.Join(JsonDecodeError, err)
+	}
+	return &v, nil
+}
+
+// Data type constraint of entities which can be fetched one by one with [GetByID].
+type SingleEntity interface {
+	Project | Issue | TimeEntry | User
+}
+
+// Get a single Redmine entity by id, include is a list of associated data to fetch along
+// with the entity (merged with [ApiClient.DefaultIncludes]), 404 is [NotFoundError].
+func GetByID[E SingleEntity](ac *ApiClient, id int, include ...string) (*E, error) {
+	var resource, key string
+	switch any(*new(E)).(type) {
+	case Project:
+		resource, key = "projects", "project"
+	case Issue:
+		resource, key = "issues", "issue"
+	case TimeEntry:
+		resource, key = "time_entries", "time_entry"
+	case User:
+		resource, key = "users", "user"
+	}
+	v := url.Values{}
+	setInclude(&v, ac.includes(resource, include...))
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/%s/%d.json", resource, id), &v, 0)
+	if err != nil {
+		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return GetOne[E](ac, u, key)
+}
+
+// Get all Redmine entities going through all the pages, stop on the first error
+// and return it along with the items fetched so far.
+func GetAll[E Entities](ac *ApiClient) ([]E, error) {
+	return getAll[E](context.Background(), ac)
+}
+
+// Get all entities like [GetAll], canceled along with ctx.
+func getAll[E Entities](ctx context.Context, ac *ApiClient) ([]E, error) {
+	if batches := issueIDBatches[E](ac); batches != nil {
+		var items []E
+		for _, c := range batches {
+			batch, err := getAll[E](ctx, c)
+			items = append(items, batch...)
+			if err != nil {
+				return items, err
+			}
+		}
+		return items, nil
+	}
+
+	var items []E
+	paginator := paginatorOf[E]()
+	for offset := 0; offset >= 0; {
+		if err := ctx.Err(); err != nil {
+			return items, err
+		}
+		r, err := getOffset[E](ctx, ac, offset)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, r.Items...)
+		if r.size() == 0 {
+			break
+		}
+		offset = r.next(paginator, offset)
+	}
+	return items, nil
 }
 
 // Scroll over Redmine API paginated responses. It going through all available data,
 // so it may generate a lot of http requests (depending on a size of data and pagination limit).
 //
-// The pagination of redmine is based on offset&limit,
-// but in URL you may use query string param ?page=, e.g. for 53 issues and limit=25 it will be
+// The pagination of redmine is based on offset&limit, e.g. for 53 issues and limit=25 it will be
 // three requests:
-//   - 0  25 53 - [0, 25] /issues.json?page=1 or omitted page number: /issues.json
-//   - 25 25 53 - [25, 50] /issues.json?page=2
-//   - 50 25 53 - [50, 53] /issues.json?page=3
+//   - 0  25 53 - [0, 25] /issues.json
+//   - 25 25 53 - [25, 50] /issues.json?offset=25
+//   - 50 25 53 - [50, 53] /issues.json?offset=50
 //
 // This function do this automatically and send all the data to channel,
 // if any error occurs, it will be send to the second, errors channel.
+// The next offset is tracked from the last successful page, so the failed
+// request is retried exactly from the same position. The transient errors are retried
+// according to [RetryPolicy]. After a fatal error (see [IsFatal]), e.g. [AuthError],
+// the scroll stops and both channels are closed; the same happens after the error which
+// is not retried (e.g. 404) or whose retries are exhausted, such an error is joined
+// with [ScrollStoppedError], so it is fatal as well.
 func Scroll[E Entities](ac *ApiConfig) (<-chan E, <-chan error) {
-	var p int
+	return ScrollContext[E](context.Background(), ac)
+}
+
+// Scroll over Redmine API paginated responses like [Scroll], but stop when ctx is done:
+// the in-flight request is canceled, ctx.Err() is sent to the error channel and both
+// channels are closed.
+func ScrollContext[E Entities](ctx context.Context, ac *ApiClient) (<-chan E, <-chan error) {
 	dataChan := make(chan E)
-	errChan := make(chan error)
+	// the buffer keeps the final ctx.Err() for the caller reading the data channel first
+	errChan := make(chan error, 1)
 
 	go func() {
-		defer close(dataChan)
 		defer close(errChan)
-		oneMore := true
-		for oneMore {
-			r, err := Get[E](ac, p)
+		scrollInto(ctx, ac, dataChan, errChan)
+		close(dataChan)
+		if err := ctx.Err(); err != nil {
+			select {
+			case errChan <- err:
+			default: // an unread error is pending, the caller sees it anyway
+			}
+		}
+	}()
+
+	return dataChan, errChan
+}
+
+// Scroll over Redmine API paginated responses like [Scroll], but write the data and errors
+// into caller-owned channels, so the caller controls buffering and can fan-in multiple
+// scrolls into one channel. It blocks until all the data is sent and doesn't close
+// the channels.
+func ScrollInto[E Entities](ac *ApiClient, out chan<- E, errs chan<- error) {
+	scrollInto(context.Background(), ac, out, errs)
+}
+
+func scrollInto[E Entities](ctx context.Context, ac *ApiClient, out chan<- E, errs chan<- error) {
+	if batches := issueIDBatches[E](ac); batches != nil {
+		for _, c := range batches {
+			scrollFrom(ctx, c, 0, out, errs, nil, nil)
+		}
+		return
+	}
+	scrollFrom(ctx, ac, 0, out, errs, nil, nil)
+}
+
+// Split too long list of issue ids into copies of client with batches of [MaxIssueIDs] ids,
+// so the query doesn't hit the URL length limits, nil if no split is needed. The copies
+// share the state of client.
+func issueIDBatches[E Entities](ac *ApiClient) []*ApiClient {
+	if _, ok := any(*new(E)).(Issue); !ok || len(ac.IssueIDs) <= MaxIssueIDs {
+		return nil
+	}
+	var batches []*ApiClient
+	for i := 0; i < len(ac.IssueIDs); i += MaxIssueIDs {
+		c := ac.clone()
+		c.IssueIDs = ac.IssueIDs[i:min(i+MaxIssueIDs, len(ac.IssueIDs))]
+		batches = append(batches, c)
+	}
+	return batches
+}
+
+// Merge the page of the first batch of issue ids (see [issueIDBatches]), fetched at
+// the requested position, with the following batches as if it were a single query:
+// the page is filled up from the next batches and the total count is the sum of totals
+// of all the batches, so the offsets of the next pages stay valid.
+func mergeBatches[E Entities](ctx context.Context, batches []*ApiClient, first *ApiResponse[E]) (*ApiResponse[E], error) {
+	r := *first
+	seen := first.Total // number of items in the batches before the current one
+	for _, c := range batches[1:] {
+		if r.Limit > 0 && r.size() >= r.Limit {
+			// the page is full, only the total count of batch is needed
+			n, err := Count[E](c)
 			if err != nil {
-				// first of all send error to err channel
-				errChan <- err
-				// analyze error and perform appropriate action
-				switch {
-				case errors.Is(err, JsonDecodeError):
-					log.Println(err)
-				case errors.Is(err, IoReadError):
-					log.Println(err)
-				case errors.Is(err, ApiEndpointUrlFatalError):
-					log.Println("fatal error: ", err)
-					break
-				case errors.Is(err, ApiNewRequestFatalError):
-					log.Println("fatal error: ", err)
-					break
-				case errors.Is(err, HttpError):
-					log.Println(err)
-					// TODO control retries: count and delay...
-				}
-				continue
+				return nil, err
+			}
+			seen += n
+			continue
+		}
+		p, err := getOffset[E](ctx, c, max(r.Offset+r.size()-seen, 0))
+		if err != nil {
+			return nil, err
+		}
+		r.Items = append(r.Items, p.Items...)
+		r.DecodeErrors = append(r.DecodeErrors, p.DecodeErrors...)
+		seen += p.Total
+	}
+	if r.Limit > 0 && len(r.Items) > r.Limit {
+		r.Items = r.Items[:r.Limit]
+	}
+	r.Total = seen
+	return &r, nil
+}
+
+// Scroll starting from the given offset, onPage (if not nil) is called after all items
+// of page are sent with the pagination of page and the offset of next one (negative if
+// there are no more pages), the items for which skip (if not nil) returns true are not sent.
+func scrollFrom[E Entities](ctx context.Context, ac *ApiClient, offset int, out chan<- E, errs chan<- error,
+	onPage func(p Pagination, next int), skip func(E) bool) {
+	paginator := paginatorOf[E]()
+	oneMore := true
+	attempt := 0
+	for oneMore && ctx.Err() == nil {
+		r, err := getOffset[E](ctx, ac, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// decode and read errors (e.g. HTML page of proxy, truncated body) are retried
+			// like the transient http errors, so a malformed page doesn't loop forever
+			rp := ac.retryPolicy()
+			stop := !retryable(err) || !rp.Allow(attempt)
+			if stop && !IsFatal(err) {
+				// let the consumer know the stream is dead
+				err = errors.Join(err, ScrollStoppedError)
 			}
+			// first of all send error to err channel
+			if !sendContext(ctx, errs, err) {
+				return
+			}
+			if stop {
+				// the stream is dead, the data channel is closed by the caller
+				ac.logf("fatal error: %s", err)
+				return
+			}
+			ac.logf("error: %s", err)
+			// the same page is retried after the delay requested by server, if longer
+			delay := max(rp.Delay(attempt), RetryAfter(err))
+			ac.logRetry(attempt, delay, err)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+			attempt++
+			continue
+		}
+		attempt = 0
+		for _, err := range r.DecodeErrors {
+			if !sendContext(ctx, errs, err) {
+				return
+			}
+		}
+		if len(r.Items) > 0 {
+			page := 1
 			if r.Limit > 0 {
-				p = (r.Offset+r.Limit)/r.Limit + 1
+				page = r.Offset/r.Limit + 1
+			}
+			ac.logf("fetched page %d (items %d-%d of %d)", page, r.Offset+1, r.Offset+len(r.Items), r.Total)
+		}
+		// track the next offset from the last successful page, so a retry after error
+		// resumes exactly where it left off
+		offset = r.next(paginator, offset)
+		oneMore = r.size() > 0 && offset >= 0
+		for _, v := range r.Items {
+			if skip != nil && skip(v) {
+				continue
 			}
-			oneMore = r.Total-r.Offset > r.Limit
-			for _, v := range r.Items {
-				dataChan <- v
+			if !sendContext(ctx, out, v) {
+				return
 			}
 		}
-	}()
+		if onPage != nil {
+			if !oneMore {
+				offset = -1
+			}
+			onPage(r.Pagination, offset)
+		}
+	}
+}
 
-	return dataChan, errChan
+// Send value to channel unless ctx is done, returns false if the value is not sent.
+func sendContext[T any](ctx context.Context, ch chan<- T, v T) bool {
+	select {
+	case ch <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
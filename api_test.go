@@ -1,13 +1,21 @@
 package redmine
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"text/template"
 	"time"
@@ -149,6 +157,13 @@ func GetResponseParamsFromUrl(qs string) *ApiResponseParams {
 			}
 			p.Offset = PaginationLimit * (pageNumber - 1)
 		}
+		if offset := v.Get("offset"); offset != "" {
+			o, err := strconv.Atoi(offset)
+			if err != nil {
+				panic(err)
+			}
+			p.Offset = o
+		}
 	}
 	p.First = p.Offset + 1
 	p.Last = p.Offset + PaginationLimit
@@ -165,13 +180,7 @@ func CreateApiConfig(url string) (ac *ApiConfig) {
 		time.Now().Add(time.Hour * 24 * 10),
 		"1",
 	}
-	apiConfig := ApiConfig{
-		url,
-		"ababab",
-		true,
-		timeEntriesFilter,
-	}
-	return &apiConfig
+	return CreateApiClient(url, "ababab", true, timeEntriesFilter)
 }
 
 // Test scroll over Redmine REST API paginated JSON resposes
@@ -268,8 +277,8 @@ func TestScroll(t *testing.T) {
 		case x := <-dataChan:
 			t.Fatalf("expected not found error, got: %v", x)
 		case err := <-errChan:
-			if !errors.Is(err, JsonDecodeError) {
-				t.Fatalf("expected JsonDecodeError, got: %s", err)
+			if !errors.Is(err, HttpError) || !errors.Is(err, NotFoundError) || errors.Is(err, JsonDecodeError) {
+				t.Fatalf("expected HttpError, got: %s", err)
 			}
 			return
 		case <-time.After(time.Second * 10):
@@ -313,6 +322,162 @@ func TestScroll(t *testing.T) {
 	})
 }
 
+// test resuming from the same offset after errors
+func TestScrollResumeAfterError(t *testing.T) {
+	failures := 2
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		if params.Offset == 2*PaginationLimit && failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(GenerateJSON(IssuesJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.Retry.BaseDelay = time.Millisecond
+	dataChan, errChan := Scroll[Issue](ac)
+
+	seen := make(map[int]int)
+	var errs int
+	for dataChan != nil || errChan != nil {
+		select {
+		case i, ok := <-dataChan:
+			if !ok {
+				dataChan = nil
+				continue
+			}
+			seen[i.Id]++
+		case _, ok := <-errChan:
+			if !ok {
+				errChan = nil
+				continue
+			}
+			errs++
+		}
+	}
+
+	if errs != 2 {
+		t.Errorf("expected 2 errors, got: %d", errs)
+	}
+	if len(seen) != TotalCount {
+		t.Errorf("expected %d items, got: %d", TotalCount, len(seen))
+	}
+	for id, n := range seen {
+		if n != 1 {
+			t.Errorf("item %d delivered %d times", id, n)
+		}
+	}
+}
+
+// test fan-in of multiple scrolls into caller-owned channel
+func TestScrollInto(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		w.Write([]byte(GenerateJSON(IssuesJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	out := make(chan Issue, 10)
+	errs := make(chan error)
+	done := make(chan struct{})
+	for range 2 {
+		go func() {
+			ScrollInto(CreateApiConfig(testServer.URL), out, errs)
+			done <- struct{}{}
+		}()
+	}
+	go func() {
+		<-done
+		<-done
+		close(out)
+	}()
+
+	var n int
+	for range out {
+		n++
+	}
+	if n != 2*TotalCount {
+		t.Errorf("expected %d items, got: %d", 2*TotalCount, n)
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		params.Total = 2 * PaginationLimit
+		params.Last = min(params.Last, params.Total)
+		w.Write([]byte(GenerateJSON(IssuesJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	r, err := GetPage[Issue](ac, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(r.Items) != PaginationLimit {
+		t.Errorf("expected %d items, got: %d", PaginationLimit, len(r.Items))
+	}
+
+	r, err = GetPage[Issue](ac, 10)
+	if !errors.Is(err, PageOutOfRangeError) {
+		t.Errorf("expected PageOutOfRangeError, got: %s", err)
+	}
+	if r == nil || len(r.Items) != 0 {
+		t.Errorf("expected empty response, got: %v", r)
+	}
+}
+
+type fakeLogger struct{ lines []string }
+
+func (l *fakeLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestScrollProgressLogging(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	for _, enabled := range []bool{true, false} {
+		logger := fakeLogger{}
+		ac := CreateApiConfig(testServer.URL)
+		ac.LogEnabled = enabled
+		ac.Logger = &logger
+		dataChan, _ := Scroll[Project](ac)
+		for range dataChan {
+		}
+
+		var progress []string
+		for _, l := range logger.lines {
+			if strings.HasPrefix(l, "fetched page") {
+				progress = append(progress, l)
+			}
+		}
+		if !enabled {
+			if len(logger.lines) != 0 {
+				t.Errorf("expected silent logger, got: %v", logger.lines)
+			}
+			continue
+		}
+		if len(progress) != 5 {
+			t.Fatalf("expected 5 progress lines, got: %v", progress)
+		}
+		if progress[0] != "fetched page 1 (items 1-25 of 110)" || progress[4] != "fetched page 5 (items 101-110 of 110)" {
+			t.Errorf("unexpected progress: %v", progress)
+		}
+	}
+}
+
 type fakeReadCloser struct{}
 
 func (f *fakeReadCloser) Read(b []byte) (n int, err error) {
@@ -328,20 +493,50 @@ func TestDecodeResp(t *testing.T) {
 	}
 }
 
+func TestDecodeRespLenient(t *testing.T) {
+	data := `{"time_entries": [
+		{"id": 1, "hours": 1.5, "spent_on": "2024-03-01"},
+		{"id": 2, "hours": 2, "spent_on": "03/02/2024"},
+		{"id": 3, "hours": 0.25, "spent_on": "2024-03-03"},
+		{"id": "4", "hours": 1}
+	], "total_count": 4, "offset": 0, "limit": 25}`
+	body := func() io.ReadCloser { return io.NopCloser(strings.NewReader(data)) }
+
+	if _, err := DecodeResp[TimeEntry](body()); !errors.Is(err, JsonDecodeError) {
+		t.Errorf("expected JsonDecodeError, got: %s", err)
+	}
+
+	r, err := DecodeRespWith[TimeEntry](body(), DecodeOptions{Lenient: true, UseNumber: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(r.Items) != 2 || r.Items[0].Id != 1 || r.Items[1].Id != 3 || r.Items[1].RawHours != "0.25" {
+		t.Errorf("unexpected items: %+v", r.Items)
+	}
+	if r.Total != 4 || len(r.DecodeErrors) != 2 {
+		t.Fatalf("unexpected response: %+v", r)
+	}
+	for i, idx := range []string{"item 1:", "item 3:"} {
+		if err := r.DecodeErrors[i]; !errors.Is(err, JsonDecodeError) || !strings.Contains(err.Error(), idx) {
+			t.Errorf("expected JsonDecodeError of %s got: %s", idx, err)
+		}
+	}
+}
+
 func TestEntityFormatting(t *testing.T) {
 	t.Run("issue", func(t *testing.T) {
-		i := Issue{1, "subj", "desc", Project{1, "project", "", "", false}}
+		i := Issue{Id: 1, Subject: "subj", Desc: "desc", Project: Project{Id: 1, Name: "project"}}
 		expected := "1     project subj"
 		if i.String() != expected {
 			t.Errorf("expected %s, got: %s", expected, i.String())
 		}
 	})
 	t.Run("time entry", func(t *testing.T) {
-		u := User{1, "user"}
-		p := Project{1, "project", "", "", false}
-		i := Issue{1, "subj", "desc", p}
+		u := User{Id: 1, Name: "user"}
+		p := Project{Id: 1, Name: "project"}
+		i := Issue{Id: 1, Subject: "subj", Desc: "desc", Project: p}
 		d := Date{}
-		te := TimeEntry{1, p, i, u, 7.35, "working", d}
+		te := TimeEntry{Id: 1, Project: p, Issue: i, User: u, Hours: 7.35, Comment: "working", SpentOn: d}
 		expected := "1      7.35 0001-01-01 user            working"
 		if te.String() != expected {
 			t.Errorf("expected %s, got: %s", expected, te.String())
@@ -358,3 +553,441 @@ func TestUnmarshalJSON2Date(t *testing.T) {
 		t.Errorf("expected JsonDecodeError, got: %s", err)
 	}
 }
+
+func TestScrollWithoutPagination(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		var items []string
+		for i := offset + 1; i <= min(offset+10, 25); i++ {
+			items = append(items, fmt.Sprintf(`{"id": %d, "name": "Project %d"}`, i, i))
+		}
+		fmt.Fprintf(w, `{"projects": [%s]}`, strings.Join(items, ","))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	dataChan, _ := Scroll[Project](CreateApiConfig(testServer.URL))
+	i := 0
+	for p := range dataChan {
+		i++
+		if p.Id != i {
+			t.Errorf("expected %d, got %d", i, p.Id)
+		}
+	}
+	if i != 25 {
+		t.Errorf("expected 25 items, got: %d", i)
+	}
+}
+
+func TestScrollFollowsServerPagination(t *testing.T) {
+	var offsets []int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, offset)
+		// the server clamps the limit to 7 whatever the client asked for
+		params := ApiResponseParams{
+			First: offset + 1, Last: min(offset+7, 30), Offset: offset, Limit: 7, Total: 30}
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	dataChan, _ := Scroll[Project](CreateApiConfig(testServer.URL))
+	i := 0
+	for p := range dataChan {
+		i++
+		if p.Id != i {
+			t.Errorf("expected %d, got %d", i, p.Id)
+		}
+	}
+	if i != 30 {
+		t.Errorf("expected 30 items, got: %d", i)
+	}
+	if !slices.Equal(offsets, []int{0, 7, 14, 21, 28}) {
+		t.Errorf("unexpected offsets: %v", offsets)
+	}
+}
+
+func TestApiDisabled(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<!DOCTYPE html><html><body><form id="login-form"></form></body></html>`))
+	})
+	mux.HandleFunc("/disabled/projects.json", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/login?back_url=%2Fprojects.json", http.StatusFound)
+	})
+	mux.HandleFunc("/forbidden/projects.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("REST web service is disabled"))
+	})
+	mux.HandleFunc("/denied/projects.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	testServer := httptest.NewServer(mux)
+	defer testServer.Close()
+
+	for prefix, disabled := range map[string]bool{"disabled": true, "forbidden": true, "denied": false} {
+		_, err := GetOffset[Project](CreateApiConfig(testServer.URL+"/"+prefix), 0)
+		if disabled && !errors.Is(err, HttpError) {
+			t.Errorf("%s: expected HttpError, got: %s", prefix, err)
+		}
+		if errors.Is(err, ApiDisabledError) != disabled {
+			t.Errorf("%s: expected ApiDisabledError to be %t, got: %s", prefix, disabled, err)
+		}
+	}
+}
+
+func TestFlexBool(t *testing.T) {
+	for data, expected := range map[string]bool{
+		`true`: true, `false`: false, `1`: true, `0`: false,
+		`"1"`: true, `"0"`: false, `"true"`: true, `"false"`: false, `null`: false,
+	} {
+		var p Project
+		if err := json.Unmarshal([]byte(`{"id": 1, "is_public": `+data+`}`), &p); err != nil {
+			t.Errorf("%s: unexpected error: %s", data, err)
+			continue
+		}
+		if bool(p.IsPublic) != expected {
+			t.Errorf("%s: expected %t, got %t", data, expected, p.IsPublic)
+		}
+	}
+
+	var b FlexBool
+	if err := json.Unmarshal([]byte(`"yes"`), &b); !errors.Is(err, JsonDecodeError) {
+		t.Errorf("expected JsonDecodeError, got: %s", err)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var ids []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get("X-Request-Id"))
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	logger := fakeLogger{}
+	ac := CreateApiConfig(testServer.URL)
+	ac.RequestIDHeader = "X-Request-Id"
+	ac.LogEnabled = true
+	ac.Logger = &logger
+	for range 3 {
+		if _, err := GetOffset[Project](ac, 0); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if len(ids) != 3 || ids[0] == "" || ids[0] == ids[1] || ids[1] == ids[2] || ids[0] == ids[2] {
+		t.Errorf("expected unique request ids, got: %v", ids)
+	}
+	if !strings.Contains(logger.lines[0], "X-Request-Id: "+ids[0]) {
+		t.Errorf("expected request id in log, got: %s", logger.lines[0])
+	}
+
+	ids = nil
+	ac.RequestID = func() string { return "trace-1" }
+	GetOffset[Project](ac, 0)
+	if len(ids) != 1 || ids[0] != "trace-1" {
+		t.Errorf("expected supplied request id, got: %v", ids)
+	}
+}
+
+func TestLastResponse(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		w.Header().Set("ETag", `"abc"`)
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	if last := ac.LastResponse(); last.StatusCode != 0 || last.Header != nil {
+		t.Errorf("expected zero response info, got: %+v", last)
+	}
+	if _, err := GetOffset[Project](ac, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	last := ac.LastResponse()
+	if last.StatusCode != http.StatusOK || last.Header.Get("X-RateLimit-Remaining") != "41" ||
+		last.Header.Get("ETag") != `"abc"` {
+		t.Errorf("unexpected response info: %+v", last)
+	}
+}
+
+func TestLastResponseOfCopies(t *testing.T) {
+	var requests int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(100-n)))
+		w.Write([]byte(`{"issues": [], "offset": 0, "limit": 1, "total_count": 3}`))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	// CountIssues sends requests with internal copy of client
+	ac := CreateApiConfig(testServer.URL)
+	if _, err := CountIssues(ac, IssuesFilter{StatusID: "*"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := strconv.Itoa(int(100 - atomic.LoadInt32(&requests)))
+	if last := ac.LastResponse(); last.Header.Get("X-RateLimit-Remaining") != expected {
+		t.Errorf("expected the last response of copy, got: %+v", last)
+	}
+}
+
+func TestScrollContext(t *testing.T) {
+	var requests int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 1 {
+			// hang until the request is canceled
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dataChan, errChan := ScrollContext[Project](ctx, CreateApiConfig(testServer.URL))
+	items := 0
+	for range dataChan {
+		items++
+		if items == PaginationLimit {
+			cancel()
+		}
+	}
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", errs)
+	}
+	if items != PaginationLimit {
+		t.Errorf("expected %d items, got: %d", PaginationLimit, items)
+	}
+}
+
+func TestGetWithContext(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow.json":
+			<-r.Context().Done()
+		case "/ok.json":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	res, err := ac.GetWithContext(context.Background(), testServer.URL+"/ok.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err = ac.GetWithContext(ctx, testServer.URL+"/slow.json"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got: %s", err)
+	}
+	if _, err = ac.GetWithContext(context.Background(), testServer.URL+"/missing.json"); !errors.Is(err, NotFoundError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+}
+
+func TestScrollWithLimit(t *testing.T) {
+	var requests int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		params := ApiResponseParams{
+			First: offset + 1, Last: min(offset+limit, TotalCount), Offset: offset, Limit: limit, Total: TotalCount}
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.Limit = 100
+	dataChan, _ := Scroll[Project](ac)
+	i := 0
+	for p := range dataChan {
+		i++
+		if p.Id != i {
+			t.Errorf("expected %d, got %d", i, p.Id)
+		}
+	}
+	if i != TotalCount || requests != 2 {
+		t.Errorf("expected %d items in 2 requests, got: %d in %d", TotalCount, i, requests)
+	}
+}
+
+func TestGetOne(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/issues/156.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"issue": {"id": 156, "subject": "Subject 156"}}`))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	issue, err := GetOne[Issue](ac, testServer.URL+"/issues/156.json", "issue")
+	if err != nil || issue.Id != 156 || issue.Subject != "Subject 156" {
+		t.Errorf("unexpected issue: %+v, %v", issue, err)
+	}
+	if _, err = GetOne[Issue](ac, testServer.URL+"/issues/156.json", "issues"); !errors.Is(err, JsonDecodeError) {
+		t.Errorf("expected JsonDecodeError, got: %s", err)
+	}
+	if _, err = GetOne[Issue](ac, testServer.URL+"/issues/157.json", "issue"); !errors.Is(err, NotFoundError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+}
+
+// round tripper counting requests, to check that the configured client is used
+type countingTransport struct{ n int32 }
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.n, 1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestHTTPClient(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow.json" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	if DefaultHTTPClient.Timeout != DefaultTimeout {
+		t.Errorf("expected default timeout %s, got: %s", DefaultTimeout, DefaultHTTPClient.Timeout)
+	}
+
+	tr := &countingTransport{}
+	ac := CreateApiConfig(testServer.URL)
+	ac.HTTPClient = &http.Client{Transport: tr, Timeout: 50 * time.Millisecond}
+	if _, err := Get[Project](ac, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := atomic.LoadInt32(&tr.n); n != 1 {
+		t.Errorf("expected 1 request via custom transport, got: %d", n)
+	}
+	res, err := ac.GetWithContext(context.Background(), testServer.URL+"/slow.json")
+	if err == nil {
+		res.Body.Close()
+		t.Errorf("expected timeout error of custom client")
+	}
+}
+
+func TestGetStatusError(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Internal error: database is locked"))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	_, err := Get[Project](CreateApiConfig(testServer.URL), 1)
+	if !errors.Is(err, HttpError) || errors.Is(err, JsonDecodeError) {
+		t.Fatalf("expected HttpError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "500") || !strings.Contains(err.Error(), "database is locked") {
+		t.Errorf("expected status and body in error, got: %s", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/issues/7.json":
+			if inc := r.URL.Query().Get("include"); inc != "journals" {
+				t.Errorf("expected include=journals, got: %s", inc)
+			}
+			w.Write([]byte(`{"issue": {"id": 7, "subject": "Seven", "journals": [{"id": 1, "notes": "hi"}]}}`))
+		case "/projects/2.json":
+			w.Write([]byte(`{"project": {"id": 2, "name": "Two", "identifier": "two"}}`))
+		case "/time_entries/3.json":
+			w.Write([]byte(`{"time_entry": {"id": 3, "hours": 1.5, "spent_on": "2024-03-01"}}`))
+		case "/users/4.json":
+			w.Write([]byte(`{"user": {"id": 4, "login": "four"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	issue, err := GetByID[Issue](ac, 7, IncludeJournals)
+	if err != nil || issue.Subject != "Seven" || len(issue.Journals) != 1 {
+		t.Errorf("unexpected issue: %+v, %v", issue, err)
+	}
+	project, err := GetByID[Project](ac, 2)
+	if err != nil || project.Ident != "two" {
+		t.Errorf("unexpected project: %+v, %v", project, err)
+	}
+	entry, err := GetByID[TimeEntry](ac, 3)
+	if err != nil || entry.Hours != 1.5 {
+		t.Errorf("unexpected time entry: %+v, %v", entry, err)
+	}
+	user, err := GetByID[User](ac, 4)
+	if err != nil || user.Login != "four" {
+		t.Errorf("unexpected user: %+v, %v", user, err)
+	}
+	if _, err = GetByID[Project](ac, 5); !errors.Is(err, NotFoundError) {
+		t.Errorf("expected NotFoundError, got: %v", err)
+	}
+}
+
+func TestScrollErrorsLogging(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	var stderr bytes.Buffer
+	log.SetOutput(&stderr)
+	defer log.SetOutput(os.Stderr)
+
+	scroll := func(ac *ApiClient) {
+		dataChan, errChan := Scroll[Project](ac)
+		go func() {
+			for range dataChan {
+			}
+		}()
+		for range errChan {
+		}
+	}
+
+	ac := CreateApiConfig(testServer.URL)
+	logger := fakeLogger{}
+	ac.Logger = &logger
+	scroll(ac)
+	if !slices.ContainsFunc(logger.lines, func(l string) bool { return strings.HasPrefix(l, "fatal error: ") }) {
+		t.Errorf("expected fatal error logged via Logger, got: %q", logger.lines)
+	}
+
+	ac = CreateApiConfig(testServer.URL)
+	ac.LogEnabled = false
+	scroll(ac)
+	if stderr.Len() > 0 {
+		t.Errorf("expected no output of standard logger, got: %s", stderr.String())
+	}
+}
@@ -0,0 +1,48 @@
+package redmine
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+)
+
+// Export the issue with its journals and attachments as zip archive written to w:
+// issue.json with the issue itself and attachments/<id>-<filename> with the content of every
+// attachment. The attachments are streamed into the archive one by one, without buffering.
+func (ac *ApiClient) ArchiveIssue(issueID int, w io.Writer) error {
+	issue, err := ac.GetIssue(issueID, "journals", "attachments")
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	f, err := zw.Create("issue.json")
+	if err != nil {
+		return errors.Join(IoWriteError, err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(issue); err != nil {
+		return errors.Join(JsonEncodeError, err)
+	}
+
+	for _, a := range issue.Attachments {
+		// prefix with id: the file names of attachments are not unique
+		name := fmt.Sprintf("attachments/%d-%s", a.Id, path.Base(a.Filename))
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: a.CreatedOn.Time})
+		if err != nil {
+			return errors.Join(IoWriteError, err)
+		}
+		if err = ac.downloadContent(&a, f); err != nil {
+			return err
+		}
+	}
+
+	if err = zw.Close(); err != nil {
+		return errors.Join(IoWriteError, err)
+	}
+	return nil
+}
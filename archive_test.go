@@ -0,0 +1,68 @@
+package redmine
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestArchiveIssue(t *testing.T) {
+	var testServer *httptest.Server
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/issues/1.json":
+			if r.URL.Query().Get("include") != "journals,attachments" {
+				t.Errorf("unexpected include: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"issue": {"id": 1, "subject": "Subject 1",
+				"journals": [{"id": 1, "notes": "note"}],
+				"attachments": [
+					{"id": 5, "filename": "log.txt", "content_url": "` + testServer.URL + `/attachments/download/5/log.txt"},
+					{"id": 6, "filename": "log.txt", "content_url": "` + testServer.URL + `/attachments/download/6/log.txt"}
+				]}}`))
+		case "/attachments/download/5/log.txt":
+			w.Write([]byte("first"))
+		case "/attachments/download/6/log.txt":
+			w.Write([]byte("second"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer = httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	var buf bytes.Buffer
+	if err := CreateApiConfig(testServer.URL).ArchiveIssue(1, &buf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	if len(files) != 3 || files["attachments/5-log.txt"] != "first" || files["attachments/6-log.txt"] != "second" {
+		t.Errorf("unexpected files: %v", files)
+	}
+	var issue Issue
+	if err := json.Unmarshal([]byte(files["issue.json"]), &issue); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if issue.Id != 1 || len(issue.Journals) != 1 || len(issue.Attachments) != 2 {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+
+	if err := CreateApiConfig(testServer.URL).ArchiveIssue(2, io.Discard); err == nil {
+		t.Errorf("expected error for missing issue")
+	}
+}
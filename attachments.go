@@ -0,0 +1,184 @@
+package redmine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// A Redmine attachment entity.
+type Attachment struct {
+	Id          int      `json:"id"`
+	Filename    string   `json:"filename"`
+	Filesize    int64    `json:"filesize"`
+	ContentType string   `json:"content_type"`
+	Description string   `json:"description"`
+	ContentUrl  string   `json:"content_url"`
+	Author      NamedRef `json:"author"`
+	CreatedOn   DateTime `json:"created_on"`
+}
+
+const UploadsEndpoint = "/uploads.json"
+
+// A reference to file uploaded to /uploads.json, used to attach the file to issue.
+type UploadRef struct {
+	Token       string `json:"token"`
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Construct the URL of attachment metadata.
+func (ac *ApiClient) AttachmentUrl(id int) (string, error) {
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/attachments/%d.json", id), &url.Values{}, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get the attachment metadata.
+func (ac *ApiClient) GetAttachment(id int) (*Attachment, error) {
+	u, err := ac.AttachmentUrl(id)
+	if err != nil {
+		return nil, err
+	}
+	return GetOne[Attachment](ac, u, "attachment")
+}
+
+// Download the attachment content to w.
+func (ac *ApiClient) DownloadAttachment(id int, w io.Writer) error {
+	a, err := ac.GetAttachment(id)
+	if err != nil {
+		return err
+	}
+	return ac.downloadContent(a, w)
+}
+
+// Download the content of attachment with known metadata to w.
+func (ac *ApiClient) downloadContent(a *Attachment, w io.Writer) error {
+	res, err := ac.do(http.MethodGet, a.ContentUrl, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err = checkStatus(res); err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, res.Body); err != nil {
+		return errors.Join(IoReadError, err)
+	}
+	return nil
+}
+
+// Download the attachment content to w resuming the interrupted download: the content is
+// requested from the current size of w (range request) and appended to it.
+//
+// If the server ignores the range and sends the full content (200 OK instead of
+// 206 Partial Content), the download is restarted from the beginning of w, w is truncated
+// if it has Truncate(size int64) error method (like [os.File]). The already completed
+// download (416 Range Not Satisfiable) is not an error.
+func (ac *ApiClient) DownloadAttachmentResume(id int, w io.WriteSeeker) error {
+	a, err := ac.GetAttachment(id)
+	if err != nil {
+		return err
+	}
+	offset, err := w.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	req, err := ac.newRequest(context.Background(), http.MethodGet, a.ContentUrl, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	res, err := ac.send(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+		// append to the end of w, it is already there
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset >= a.Filesize {
+			return nil
+		}
+		return checkStatus(res)
+	case http.StatusOK:
+		if offset > 0 {
+			if _, err = w.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if t, ok := w.(interface{ Truncate(int64) error }); ok {
+				if err = t.Truncate(0); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return checkStatus(res)
+	}
+
+	if _, err = io.Copy(w, res.Body); err != nil {
+		return errors.Join(IoReadError, err)
+	}
+	return nil
+}
+
+// Upload the file content to /uploads.json, the returned reference is used to attach
+// the file to issue, see [ApiClient.AttachToIssue].
+func (ac *ApiClient) Upload(filename string, r io.Reader) (*UploadRef, error) {
+	v := url.Values{}
+	v.Set("filename", filename)
+	u, err := BuildApiUrl(ac.Url, UploadsEndpoint, &v, 0)
+	if err != nil {
+		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+
+	res, err := ac.doContent(http.MethodPost, u, "application/octet-stream", r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return nil, responseError(res)
+	}
+
+	var resp struct {
+		Upload UploadRef `json:"upload"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, errors.Join(JsonDecodeError, err)
+	}
+	resp.Upload.Filename = filename
+	return &resp.Upload, nil
+}
+
+// Attach the uploaded files to existing issue with optional note.
+func (ac *ApiClient) AttachToIssue(issueID int, uploads []UploadRef, note string) error {
+	u, err := ac.IssueUrl(issueID)
+	if err != nil {
+		return err
+	}
+
+	type attachment struct {
+		Uploads []UploadRef `json:"uploads"`
+		Notes   string      `json:"notes,omitempty"`
+	}
+	data, err := json.Marshal(PostEnvelope[attachment]{"issue", attachment{uploads, note}})
+	if err != nil {
+		return errors.Join(JsonEncodeError, err)
+	}
+	return ac.Update(u, bytes.NewReader(data))
+}
@@ -0,0 +1,148 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAttachToIssue(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got: %s", r.Method)
+		}
+		if r.URL.Path != "/issues/1.json" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors": ["Attachment is invalid"]}`))
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		expected := `{"issue":{"uploads":[{"token":"7167.ed1ccdb","filename":"a.png","content_type":"image/png"}],"notes":"screenshot"}}`
+		if string(b) != expected {
+			t.Errorf("expected %s, got: %s", expected, b)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	uploads := []UploadRef{{Token: "7167.ed1ccdb", Filename: "a.png", ContentType: "image/png"}}
+	if err := ac.AttachToIssue(1, uploads, "screenshot"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err := ac.AttachToIssue(2, uploads, "screenshot")
+	if !errors.Is(err, HttpError) || !strings.Contains(err.Error(), "Attachment is invalid") {
+		t.Errorf("expected validation error, got: %s", err)
+	}
+}
+
+func TestDownloadAttachmentResume(t *testing.T) {
+	const content = "0123456789"
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/attachments/1.json", "/attachments/2.json":
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/attachments/"), ".json")
+			fmt.Fprintf(w, `{"attachment": {"id": %s, "filename": "a.txt", "filesize": %d,
+				"content_url": "http://%s/attachments/download/%s/a.txt"}}`, id, len(content), r.Host, id)
+		case "/attachments/download/1/a.txt":
+			// supports range requests
+			http.ServeContent(w, r, "a.txt", time.Time{}, strings.NewReader(content))
+		case "/attachments/download/2/a.txt":
+			// ignores range requests
+			w.Write([]byte(content))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	for _, id := range []int{1, 2} {
+		for _, partial := range []string{"", "01234", content} {
+			f, err := os.CreateTemp(t.TempDir(), "a.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.WriteString(partial)
+
+			if err = ac.DownloadAttachmentResume(id, f); err != nil {
+				t.Errorf("%d, %q: unexpected error: %s", id, partial, err)
+			}
+			f.Close()
+			if b, _ := os.ReadFile(f.Name()); string(b) != content {
+				t.Errorf("%d, %q: expected %s, got: %s", id, partial, content, b)
+			}
+		}
+	}
+
+	var b strings.Builder
+	if err := ac.DownloadAttachment(1, &b); err != nil || b.String() != content {
+		t.Errorf("expected %s, got: %s (%v)", content, b.String(), err)
+	}
+	if err := ac.DownloadAttachment(3, &b); !errors.Is(err, NotFoundError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	var contentTypes []string
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+		b, _ := io.ReadAll(r.Body)
+		if r.URL.Path != UploadsEndpoint || r.URL.Query().Get("filename") != "a.txt" || string(b) != "content" {
+			t.Errorf("unexpected request: %s %q", r.URL, b)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"upload": {"id": 7, "token": "7.abcdef"}}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	ref, err := ac.Upload("a.txt", strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ref.Token != "7.abcdef" || ref.Filename != "a.txt" {
+		t.Errorf("unexpected upload ref: %+v", ref)
+	}
+
+	_, body, err := ac.Post(testServer.URL+UploadsEndpoint+"?filename=a.txt", strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body.Close()
+	if len(contentTypes) != 2 || contentTypes[0] != "application/octet-stream" || contentTypes[1] != "application/json" {
+		t.Errorf("unexpected content types: %v", contentTypes)
+	}
+}
+
+func TestUploadErrors(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors": ["This file cannot be uploaded because it exceeds the maximum allowed file size (5 MB)"]}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	_, err := ac.Upload("a.txt", strings.NewReader("content"))
+	if !errors.Is(err, HttpError) {
+		t.Fatalf("expected HttpError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "422") || !strings.Contains(err.Error(), "exceeds the maximum allowed file size") {
+		t.Errorf("expected status and errors of Redmine, got: %s", err)
+	}
+}
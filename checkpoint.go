@@ -0,0 +1,167 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+var (
+	CheckpointError     = errors.New("checkpoint error")
+	DatasetChangedError = errors.New("dataset changed since checkpoint")
+)
+
+// Progress of scroll persisted for resuming of long exports after crash.
+type Checkpoint struct {
+	Kind   string `json:"kind"`   // entity kind: projects, issues, time_entries
+	Offset int    `json:"offset"` // offset of the next page, negative if scroll is completed
+	Total  int    `json:"total"`  // total count observed on the last completed page
+	// Ids of items of the last completed page: if items were added since the checkpoint,
+	// the items of the last page shift to the next one, they are skipped on resume.
+	Seen []int `json:"seen,omitempty"`
+}
+
+// Check whether the scroll is completed.
+func (c Checkpoint) Done() bool { return c.Offset < 0 }
+
+// Get entity kind used in checkpoints.
+func kindOf[E Entities]() string {
+	switch any(*new(E)).(type) {
+	case Project:
+		return "projects"
+	case Issue:
+		return "issues"
+	case TimeEntry:
+		return "time_entries"
+	case User:
+		return "users"
+	}
+	return ""
+}
+
+// Get id of entity.
+func entityID[E Entities](v E) int {
+	switch v := any(v).(type) {
+	case Project:
+		return v.Id
+	case Issue:
+		return v.Id
+	case TimeEntry:
+		return v.Id
+	case User:
+		return v.Id
+	}
+	return 0
+}
+
+// Save checkpoint as JSON.
+func SaveCheckpoint(w io.Writer, c Checkpoint) error {
+	if err := json.NewEncoder(w).Encode(c); err != nil {
+		return errors.Join(CheckpointError, err)
+	}
+	return nil
+}
+
+// Load checkpoint from JSON.
+func LoadCheckpoint(r io.Reader) (Checkpoint, error) {
+	var c Checkpoint
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return c, errors.Join(CheckpointError, JsonDecodeError, err)
+	}
+	return c, nil
+}
+
+// Load checkpoint from file, the missing file means the zero checkpoint: the scroll
+// from the beginning.
+func LoadCheckpointFile(name string) (Checkpoint, error) {
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Checkpoint{}, nil
+	}
+	if err != nil {
+		return Checkpoint{}, errors.Join(CheckpointError, err)
+	}
+	defer f.Close()
+	return LoadCheckpoint(f)
+}
+
+// Save checkpoint to file atomically: it is written to a temporary file which is renamed
+// then, so the interrupted save doesn't corrupt the previous checkpoint.
+func SaveCheckpointFile(name string, c Checkpoint) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return errors.Join(CheckpointError, err)
+	}
+	defer os.Remove(f.Name()) // no-op after successful rename
+
+	if err = SaveCheckpoint(f, c); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return errors.Join(CheckpointError, err)
+	}
+	if err = os.Rename(f.Name(), name); err != nil {
+		return errors.Join(CheckpointError, err)
+	}
+	return nil
+}
+
+// Scroll like [Scroll], but resume from the checkpoint (zero checkpoint means from the
+// beginning). The checkpoint of every completed page is passed to save, so the caller
+// may persist it with [SaveCheckpoint] or [SaveCheckpointFile].
+//
+// The items of the previous page which appear again on the next one (the items shift
+// forward when new ones are added) are skipped, including the boundary page on resume.
+//
+// If the checkpoint is of another entity kind, [CheckpointError] is sent to errors channel
+// and nothing is fetched. If the total count differs from the one in checkpoint, the dataset
+// was changed and some items may be skipped or repeated: [DatasetChangedError] warning is
+// sent to errors channel and the scroll goes on.
+func ScrollFrom[E Entities](ac *ApiClient, c Checkpoint, save func(Checkpoint)) (<-chan E, <-chan error) {
+	dataChan := make(chan E)
+	errChan := make(chan error)
+	kind := kindOf[E]()
+
+	go func() {
+		defer close(dataChan)
+		defer close(errChan)
+
+		if c.Kind != "" && c.Kind != kind {
+			errChan <- errors.Join(CheckpointError, fmt.Errorf("expected %s checkpoint, got: %s", kind, c.Kind))
+			return
+		}
+		if c.Done() {
+			return
+		}
+
+		warned := false
+		seen, page := c.Seen, []int{}
+		skip := func(v E) bool {
+			id := entityID(v)
+			if slices.Contains(seen, id) {
+				return true
+			}
+			page = append(page, id)
+			return false
+		}
+		scrollFrom(context.Background(), ac, c.Offset, dataChan, errChan, func(p Pagination, next int) {
+			if c.Total > 0 && c.Total != p.Total && !warned {
+				warned = true
+				errChan <- errors.Join(DatasetChangedError, fmt.Errorf("total count %d, was %d", p.Total, c.Total))
+			}
+			if save != nil {
+				save(Checkpoint{Kind: kind, Offset: next, Total: p.Total, Seen: page})
+			}
+			seen, page = page, []int{}
+		}, skip)
+	}()
+
+	return dataChan, errChan
+}
@@ -0,0 +1,135 @@
+package redmine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestScrollFromCheckpoint(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		w.Write([]byte(GenerateJSON(IssuesJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	// "crash" after the second page
+	var buf bytes.Buffer
+	pages := 0
+	dataChan, _ := ScrollFrom[Issue](ac, Checkpoint{}, func(c Checkpoint) {
+		pages++
+		if pages == 2 {
+			buf.Reset()
+			if err := SaveCheckpoint(&buf, c); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+	})
+	for range dataChan {
+	}
+
+	c, err := LoadCheckpoint(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if c.Kind != "issues" || c.Offset != 2*PaginationLimit || c.Total != TotalCount ||
+		len(c.Seen) != PaginationLimit || c.Seen[0] != PaginationLimit+1 {
+		t.Fatalf("unexpected checkpoint: %+v", c)
+	}
+
+	var last Checkpoint
+	i := 2*PaginationLimit + 1
+	dataChan, _ = ScrollFrom[Issue](ac, c, func(c Checkpoint) { last = c })
+	for issue := range dataChan {
+		if issue.Id != i {
+			t.Errorf("expected %d, got %d", i, issue.Id)
+		}
+		i++
+	}
+	if i-1 != TotalCount || !last.Done() {
+		t.Errorf("expected completed scroll, got %d items, %+v", i-1, last)
+	}
+
+	// dataset changed
+	c.Total = TotalCount - 1
+	dataChan, errChan := ScrollFrom[Issue](ac, c, nil)
+	go func() {
+		for range dataChan {
+		}
+	}()
+	if err = <-errChan; !errors.Is(err, DatasetChangedError) {
+		t.Errorf("expected DatasetChangedError, got: %s", err)
+	}
+
+	// another entity kind
+	_, errChan = ScrollFrom[Project](ac, c, nil)
+	if err = <-errChan; !errors.Is(err, CheckpointError) {
+		t.Errorf("expected CheckpointError, got: %s", err)
+	}
+}
+
+func TestCheckpointFileResume(t *testing.T) {
+	ids := make([]int, 60)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		var items []string
+		for _, id := range ids[offset:min(offset+25, len(ids))] {
+			items = append(items, fmt.Sprintf(`{"id": %d}`, id))
+		}
+		fmt.Fprintf(w, `{"projects": [%s], "total_count": %d, "offset": %d, "limit": 25}`,
+			strings.Join(items, ","), len(ids), offset)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	name := filepath.Join(t.TempDir(), "projects.checkpoint")
+	c, err := LoadCheckpointFile(name)
+	if err != nil || c.Offset != 0 || c.Kind != "" {
+		t.Fatalf("expected zero checkpoint, got: %+v, %v", c, err)
+	}
+
+	// "crash" after the first page
+	dataChan, _ := ScrollFrom[Project](ac, c, func(c Checkpoint) {
+		if c.Offset == 25 {
+			if err := SaveCheckpointFile(name, c); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}
+	})
+	for range dataChan {
+	}
+
+	// new items are added to the beginning and shift the rest
+	ids = append([]int{101, 102, 103}, ids...)
+
+	c, err = LoadCheckpointFile(name)
+	if err != nil || c.Offset != 25 {
+		t.Fatalf("unexpected checkpoint: %+v, %v", c, err)
+	}
+	var got []int
+	dataChan, errChan := ScrollFrom[Project](ac, c, nil)
+	go func() {
+		for range errChan {
+		}
+	}()
+	for p := range dataChan {
+		got = append(got, p.Id)
+	}
+	expected := ids[3+25:]
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got: %v", expected, got)
+	}
+}
@@ -0,0 +1,84 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+)
+
+// Get a page of Redmine entities with the given limit of items per page.
+func getLimited[E Entities](ac *ApiClient, limit int) (*ApiResponse[E], error) {
+	v := url.Values{}
+	v.Set("limit", strconv.Itoa(limit))
+	u, err := apiEndpointURL[E](ac, v, 0)
+	if err != nil {
+		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+
+	res, err := ac.do(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkStatus(res); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+	r, err := DecodeRespWith[E](res.Body, ac.Decode)
+	ac.observeDecode(err)
+	return r, err
+}
+
+// Support of limit=0 by server detected by [Count].
+const (
+	limitZeroUnknown int32 = iota
+	limitZeroHonored
+	limitZeroIgnored
+)
+
+// Get the total count of Redmine entities (respecting the filtration) without downloading
+// all the pages. It prefers the cheapest limit=0 request (some Redmine versions return
+// just total_count with empty items) and falls back to limit=1 if the server returns
+// an error or a non-empty page (limit=0 is not honored, e.g. treated as default limit).
+// The detected support of limit=0 is remembered by client (and its copies, e.g. of
+// [CountIssues]), so the next counts don't waste a request on it.
+func Count[E Entities](ac *ApiClient) (int, error) {
+	if batches := issueIDBatches[E](ac); batches != nil {
+		var total int
+		for _, c := range batches {
+			n, err := Count[E](c)
+			if err != nil {
+				return 0, err
+			}
+			total += n
+		}
+		return total, nil
+	}
+
+	st := ac.shared()
+	if atomic.LoadInt32(&st.limitZero) != limitZeroIgnored {
+		r, err := getLimited[E](ac, 0)
+		if err == nil && len(r.Items) == 0 && r.Limit == 0 {
+			atomic.StoreInt32(&st.limitZero, limitZeroHonored)
+			return r.Total, nil
+		}
+		if err == nil || atomic.LoadInt32(&st.limitZero) == limitZeroUnknown {
+			// the request succeeded, but limit=0 is not honored, or the first
+			// attempt failed: it may be a server rejecting limit=0
+			atomic.StoreInt32(&st.limitZero, limitZeroIgnored)
+		}
+	}
+
+	r, err := getLimited[E](ac, 1)
+	if err != nil {
+		return 0, err
+	}
+	return r.Total, nil
+}
+
+// Count issues matching the filter like [Count], but with the given filter instead of
+// the filter of client, e.g. for dashboard of open issues per assignee.
+func CountIssues(ac *ApiClient, f IssuesFilter) (int, error) {
+	return Count[Issue](ac.withIssuesFilter(f))
+}
@@ -0,0 +1,90 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	cases := []struct {
+		name     string
+		limit0   string // server behavior on limit=0: honor, reject, ignore
+		requests int    // expected number of requests for two counts
+	}{
+		{"limit=0", "honor", 2},
+		{"fallback on error", "reject", 3},
+		{"fallback on non-empty page", "ignore", 3},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var requests int
+			handleReq := func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				params := GetResponseParamsFromUrl(r.URL.RawQuery)
+				switch r.URL.Query().Get("limit") {
+				case "0":
+					switch c.limit0 {
+					case "reject":
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						return
+					case "honor":
+						params.Limit, params.Last = 0, 0
+					}
+				case "1":
+					params.Limit, params.Last = 1, 1
+				default:
+					t.Errorf("expected limit, got: %s", r.URL.RawQuery)
+				}
+				w.Write([]byte(GenerateJSON(IssuesJSONResponseTpl, params)))
+			}
+			testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+			defer testServer.Close()
+
+			ac := CreateApiConfig(testServer.URL)
+			for range 2 {
+				n, err := Count[Issue](ac)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if n != TotalCount {
+					t.Errorf("expected %d, got: %d", TotalCount, n)
+				}
+			}
+			// the detected support of limit=0 is remembered
+			if requests != c.requests {
+				t.Errorf("expected %d requests, got: %d", c.requests, requests)
+			}
+		})
+	}
+}
+
+func TestCountIssues(t *testing.T) {
+	var requests int
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		if q.Get("status_id") != "open" || q.Get("assigned_to_id") != "3" {
+			t.Errorf("expected filter params, got: %s", r.URL.RawQuery)
+		}
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		params.Limit, params.Last = 0, 0
+		w.Write([]byte(GenerateJSON(IssuesJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	for range 2 {
+		n, err := CountIssues(ac, IssuesFilter{StatusID: "open", AssignedToID: "3"})
+		if err != nil || n != TotalCount {
+			t.Errorf("expected %d, got: %d, %v", TotalCount, n, err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got: %d", requests)
+	}
+	if ac.IssuesFilter.StatusID != "" {
+		t.Errorf("expected filter of client untouched, got: %+v", ac.IssuesFilter)
+	}
+}
@@ -0,0 +1,59 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// A value of custom field of Redmine entity, e.g. "Customer" or "Sprint" of issue.
+//
+// Redmine serializes the value of multi-value field as list of strings and the value
+// of single-value field as string, so Value is either []string or string (nil for null),
+// so it is marshaled back in the same shape.
+type CustomField struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name,omitempty"`
+	Multiple FlexBool `json:"multiple,omitempty"`
+	Value    any      `json:"value"`
+}
+
+func (cf *CustomField) UnmarshalJSON(b []byte) error {
+	type plain CustomField
+	aux := struct {
+		plain
+		Value json.RawMessage `json:"value"`
+	}{}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return errors.Join(JsonDecodeError, err)
+	}
+	*cf = CustomField(aux.plain)
+
+	var list []string
+	var s string
+	switch {
+	case len(aux.Value) == 0 || string(aux.Value) == "null":
+		cf.Value = nil
+	case json.Unmarshal(aux.Value, &list) == nil:
+		cf.Value = list
+	case json.Unmarshal(aux.Value, &s) == nil:
+		cf.Value = s
+	default:
+		// neither string nor list of strings, e.g. number of plugin field: keep as is
+		var v any
+		if err := json.Unmarshal(aux.Value, &v); err != nil {
+			return errors.Join(JsonDecodeError, err)
+		}
+		cf.Value = v
+	}
+	return nil
+}
+
+// Get the custom field of issue by id, nil if the issue has no such field.
+func (i Issue) CustomField(id int) *CustomField {
+	for k := range i.CustomFields {
+		if i.CustomFields[k].ID == id {
+			return &i.CustomFields[k]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package redmine
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestCustomFieldJSON(t *testing.T) {
+	data := `{"id": 1, "subject": "With fields", "custom_fields": [
+		{"id": 2, "name": "Customer", "value": "ACME"},
+		{"id": 3, "name": "Sprint", "multiple": "1", "value": ["S1", "S2"]},
+		{"id": 4, "name": "Empty", "value": null}]}`
+	var issue Issue
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cf := issue.CustomField(2); cf == nil || cf.Name != "Customer" || cf.Value != "ACME" {
+		t.Errorf("unexpected single-value field: %+v", cf)
+	}
+	if cf := issue.CustomField(3); cf == nil || !bool(cf.Multiple) || !slices.Equal(cf.Value.([]string), []string{"S1", "S2"}) {
+		t.Errorf("unexpected multi-value field: %+v", cf)
+	}
+	if cf := issue.CustomField(4); cf == nil || cf.Value != nil {
+		t.Errorf("unexpected empty field: %+v", cf)
+	}
+	if cf := issue.CustomField(5); cf != nil {
+		t.Errorf("expected no field, got: %+v", cf)
+	}
+
+	out, err := json.Marshal(issue.CustomFields)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `[{"id":2,"name":"Customer","value":"ACME"},` +
+		`{"id":3,"name":"Sprint","multiple":true,"value":["S1","S2"]},{"id":4,"name":"Empty","value":null}]`
+	if string(out) != expected {
+		t.Errorf("expected %s, got: %s", expected, out)
+	}
+
+	p := CreateIssuePayload{ProjectID: 1, Subject: "New", CustomFields: []CustomField{
+		{ID: 2, Value: "ACME"}, {ID: 3, Value: []string{"S1"}}}}
+	out, _ = json.Marshal(PostDataIssue{p})
+	expected = `{"issue":{"project_id":1,"subject":"New",` +
+		`"custom_fields":[{"id":2,"value":"ACME"},{"id":3,"value":["S1"]}]}}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got: %s", expected, out)
+	}
+}
+
+func TestPreviewCustomFieldChanges(t *testing.T) {
+	issue := Issue{Id: 1, CustomFields: []CustomField{{ID: 2, Value: "ACME"}, {ID: 3, Value: []string{"S1"}}}}
+	b := NewIssueUpdate().SetCustomField(2, "ACME").SetCustomField(3, []string{"S1", "S2"}).SetCustomField(4, "x")
+	changes := b.changes(issue)
+	expected := []FieldChange{{"custom_field_3", "S1", "S1, S2"}, {"custom_field_4", "", "x"}}
+	if !slices.Equal(changes, expected) {
+		t.Errorf("expected %v, got: %v", expected, changes)
+	}
+}
@@ -0,0 +1,84 @@
+package redmine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Create a date from time, see [Date]. The date is taken from the wall clock of t,
+// e.g. local for time.Now(), see [NewDateIn] for the other location.
+func NewDate(t time.Time) Date { return Date{t} }
+
+// Create a date from time as seen in the given location, e.g. the spent_on of time entry
+// in timezone of Redmine users rather than of the machine: 2024-11-01 23:30 in New York
+// is 2024-11-02 in Tokyo.
+func NewDateIn(t time.Time, loc *time.Location) Date { return Date{t.In(loc)} }
+
+// Get the current date.
+func Today() Date { return Date{time.Now()} }
+
+// Get the current date in the given location, see [NewDateIn].
+func TodayIn(loc *time.Location) Date { return NewDateIn(time.Now(), loc) }
+
+// Get the underlying time of date.
+func (d Date) ToTime() time.Time { return d.Time }
+
+// Get the midnight of wall clock date (as UTC), used for comparison at day granularity.
+func (d Date) day() time.Time {
+	y, m, dd := d.Time.Date()
+	return time.Date(y, m, dd, 0, 0, 0, 0, time.UTC)
+}
+
+// Report whether the date is before u, the time of day is ignored.
+func (d Date) Before(u Date) bool { return d.day().Before(u.day()) }
+
+// Report whether the date is after u, the time of day is ignored.
+func (d Date) After(u Date) bool { return d.day().After(u.day()) }
+
+// Report whether the date is the same day as u, the time of day is ignored.
+func (d Date) Equal(u Date) bool { return d.day().Equal(u.day()) }
+
+// Legacy layout of timestamps of old Redmine versions, e.g. "Sat Sep 29 12:03:04 +0200 2007".
+const LegacyDateTimeLayout = "Mon Jan 02 15:04:05 -0700 2006"
+
+// A timestamp type is needed for parsing of both formats of Redmine timestamps used in JSON:
+// ISO 8601 (e.g. "2007-09-29T10:03:04Z") of recent versions and [LegacyDateTimeLayout]
+// of old ones. The timestamp is marshaled back in the format it was parsed from.
+type DateTime struct {
+	time.Time
+	legacy bool // parsed from LegacyDateTimeLayout
+}
+
+func (d *DateTime) UnmarshalJSON(b []byte) error {
+	s := string(bytes.Trim(b, "\""))
+	if s == "null" || s == "" {
+		*d = DateTime{}
+		return nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		*d = DateTime{Time: t}
+		return nil
+	}
+	t, err := time.Parse(LegacyDateTimeLayout, s)
+	if err != nil {
+		return errors.Join(JsonDecodeError, fmt.Errorf("invalid timestamp: %s", b))
+	}
+	*d = DateTime{Time: t, legacy: true}
+	return nil
+}
+
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func (d DateTime) String() string {
+	if d.legacy {
+		return d.Time.Format(LegacyDateTimeLayout)
+	}
+	return d.Time.Format(time.RFC3339)
+}
@@ -0,0 +1,131 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDateWallClock(t *testing.T) {
+	// the same calendar day in different zones, including the instants which are
+	// a different day in UTC
+	for _, loc := range []*time.Location{
+		time.UTC, time.FixedZone("UTC+3", 3*3600), time.FixedZone("UTC-10", -10*3600), time.Local} {
+		for _, hour := range []int{0, 12, 23} {
+			d := Date{time.Date(2024, time.November, 1, hour, 30, 0, 0, loc)}
+			if s := d.String(); s != "2024-11-01" {
+				t.Errorf("%s %02d:30: expected 2024-11-01, got: %s", loc, hour, s)
+			}
+		}
+	}
+
+	// dates from strings are timezone agnostic
+	d, err := DateFromString("2024-11-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.String() != "2024-11-01" {
+		t.Errorf("expected 2024-11-01, got: %s", d)
+	}
+	if err = d.UnmarshalJSON([]byte(`"2024-12-31"`)); err != nil || d.String() != "2024-12-31" {
+		t.Errorf("expected 2024-12-31, got: %s (%v)", d, err)
+	}
+	if s := (Date{}).String(); s != "0001-01-01" {
+		t.Errorf("expected zero date, got: %s", s)
+	}
+}
+
+func TestNewDateIn(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*3600)
+	tokyo := time.FixedZone("JST", 9*3600)
+	// the midnight boundary: the last minute of 2024-11-01 and the first one of 2024-11-02 in New York
+	for _, c := range []struct {
+		t             time.Time
+		ny, tk, local string
+	}{
+		{time.Date(2024, 11, 1, 23, 59, 0, 0, newYork), "2024-11-01", "2024-11-02", "2024-11-01"},
+		{time.Date(2024, 11, 2, 0, 0, 0, 0, newYork), "2024-11-02", "2024-11-02", "2024-11-02"},
+		{time.Date(2024, 11, 1, 13, 59, 0, 0, tokyo), "2024-10-31", "2024-11-01", "2024-11-01"},
+	} {
+		if d := NewDateIn(c.t, newYork).String(); d != c.ny {
+			t.Errorf("%s: expected %s in New York, got: %s", c.t, c.ny, d)
+		}
+		if d := NewDateIn(c.t, tokyo).String(); d != c.tk {
+			t.Errorf("%s: expected %s in Tokyo, got: %s", c.t, c.tk, d)
+		}
+		if d := NewDate(c.t).String(); d != c.local {
+			t.Errorf("%s: expected own date %s, got: %s", c.t, c.local, d)
+		}
+	}
+
+	data, _ := json.Marshal(TimeEntry{SpentOn: NewDateIn(time.Date(2024, 11, 1, 23, 30, 0, 0, newYork), tokyo)})
+	if !strings.Contains(string(data), `"2024-11-02"`) {
+		t.Errorf("expected spent_on 2024-11-02, got: %s", data)
+	}
+	if d := TodayIn(tokyo); d.Location() != tokyo {
+		t.Errorf("expected today in Tokyo, got: %s", d.Location())
+	}
+}
+
+func TestDateComparison(t *testing.T) {
+	morning := NewDate(time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC))
+	evening := NewDate(time.Date(2024, time.March, 1, 23, 59, 0, 0, time.UTC))
+	nextDay := NewDate(time.Date(2024, time.March, 2, 0, 1, 0, 0, time.UTC))
+
+	if !morning.Equal(evening) || morning.Before(evening) || evening.After(morning) {
+		t.Errorf("expected %s and %s to be equal", morning.ToTime(), evening.ToTime())
+	}
+	if !evening.Before(nextDay) || !nextDay.After(evening) || evening.Equal(nextDay) {
+		t.Errorf("expected %s before %s", evening.ToTime(), nextDay.ToTime())
+	}
+
+	// the wall clock days are compared, not the instants
+	east := NewDate(time.Date(2024, time.March, 2, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*3600)))
+	if !east.Equal(nextDay) || !east.After(evening) {
+		t.Errorf("expected %s to be the day of %s", east.ToTime(), nextDay.ToTime())
+	}
+
+	if !Today().Equal(NewDate(time.Now())) {
+		t.Error("expected today")
+	}
+}
+
+func TestDateTimeJSON(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{`"2007-09-29T10:03:04Z"`, time.Date(2007, 9, 29, 10, 3, 4, 0, time.UTC)},
+		{`"Sat Sep 29 12:03:04 +0200 2007"`, time.Date(2007, 9, 29, 10, 3, 4, 0, time.UTC)},
+		{`null`, time.Time{}},
+	}
+	for _, c := range cases {
+		var d DateTime
+		if err := json.Unmarshal([]byte(c.in), &d); err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.in, err)
+		}
+		if !d.Equal(c.want) {
+			t.Errorf("%s: expected %s, got: %s", c.in, c.want, d.Time)
+		}
+		out, _ := json.Marshal(d)
+		if string(out) != c.in {
+			t.Errorf("expected round trip to %s, got: %s", c.in, out)
+		}
+	}
+
+	var d DateTime
+	if err := json.Unmarshal([]byte(`"yesterday"`), &d); !errors.Is(err, JsonDecodeError) {
+		t.Errorf("expected JsonDecodeError, got: %v", err)
+	}
+
+	var p Project
+	data := `{"id": 1, "created_on": "Sat Sep 29 12:03:04 +0200 2007", "updated_on": "2024-03-01T12:00:00Z"}`
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !p.UpdatedOn.After(p.CreatedOn.Time) || p.CreatedOn.Year() != 2007 {
+		t.Errorf("unexpected timestamps: %s, %s", p.CreatedOn, p.UpdatedOn)
+	}
+}
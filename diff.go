@@ -0,0 +1,43 @@
+package redmine
+
+// Compare two snapshots of scrolled entities keyed by id extractor: the items of new snapshot
+// absent in the old one are added, the items of old snapshot absent in the new one
+// are removed, changed are the items of new snapshot for which predicate isChanged
+// reports the difference with old version of the item.
+func Diff[E any, K comparable](old, new []E, key func(E) K, isChanged func(o, n E) bool) (added, changed, removed []E) {
+	prev := make(map[K]E, len(old))
+	for _, e := range old {
+		prev[key(e)] = e
+	}
+
+	seen := make(map[K]struct{}, len(new))
+	for _, e := range new {
+		k := key(e)
+		seen[k] = struct{}{}
+		o, ok := prev[k]
+		switch {
+		case !ok:
+			added = append(added, e)
+		case isChanged(o, e):
+			changed = append(changed, e)
+		}
+	}
+
+	for _, e := range old {
+		if _, ok := seen[key(e)]; !ok {
+			removed = append(removed, e)
+		}
+	}
+	return
+}
+
+// Report whether the issue was changed: subject, description, status or assignee.
+func IssueChanged(o, n Issue) bool {
+	return o.Subject != n.Subject || o.Desc != n.Desc ||
+		o.Status.Id != n.Status.Id || o.AssignedTo.Id != n.AssignedTo.Id
+}
+
+// Compare two snapshots of issues keyed by issue id, see [Diff] and [IssueChanged].
+func DiffIssues(old, new []Issue) (added, changed, removed []Issue) {
+	return Diff(old, new, func(i Issue) int { return i.Id }, IssueChanged)
+}
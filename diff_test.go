@@ -0,0 +1,42 @@
+package redmine
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffIssues(t *testing.T) {
+	old := []Issue{
+		{Id: 1, Subject: "one"},
+		{Id: 2, Subject: "two"},
+		{Id: 3, Subject: "three", Status: NamedRef{Id: 1, Name: "New"}},
+		{Id: 4, Subject: "four"},
+	}
+	new := []Issue{
+		{Id: 1, Subject: "one"},
+		{Id: 3, Subject: "three", Status: NamedRef{Id: 5, Name: "Closed"}},
+		{Id: 4, Subject: "four", AssignedTo: NamedRef{Id: 7, Name: "User7"}},
+		{Id: 5, Subject: "five"},
+	}
+
+	ids := func(issues []Issue) (res []int) {
+		for _, i := range issues {
+			res = append(res, i.Id)
+		}
+		return
+	}
+
+	added, changed, removed := DiffIssues(old, new)
+	if got := ids(added); !slices.Equal(got, []int{5}) {
+		t.Errorf("expected added [5], got: %v", got)
+	}
+	if got := ids(changed); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("expected changed [3 4], got: %v", got)
+	}
+	if got := ids(removed); !slices.Equal(got, []int{2}) {
+		t.Errorf("expected removed [2], got: %v", got)
+	}
+	if changed[0].Status.Name != "Closed" {
+		t.Errorf("expected new version of changed issue, got: %v", changed[0])
+	}
+}
@@ -0,0 +1,56 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Known kinds of enumerations.
+const (
+	EnumIssuePriorities     = "issue_priorities"
+	EnumTimeEntryActivities = "time_entry_activities"
+	EnumDocumentCategories  = "document_categories"
+)
+
+// A Redmine enumeration item: issue priority, time entry activity, document category etc.
+type Enumeration struct {
+	Id        int      `json:"id"`
+	Name      string   `json:"name"`
+	IsDefault FlexBool `json:"is_default"`
+	Active    FlexBool `json:"active"`
+}
+
+// Construct the URL of enumeration of the given kind, e.g. [EnumIssuePriorities].
+func (ac *ApiClient) EnumerationUrl(kind string) (string, error) {
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/enumerations/%s.json", kind), &url.Values{}, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get the items of enumeration of the given kind, all the enumerations share the same
+// shape: {"<kind>": [{id, name, is_default, active}]}, the endpoints are not paginated.
+func (ac *ApiClient) GetEnumeration(kind string) ([]Enumeration, error) {
+	u, err := ac.EnumerationUrl(kind)
+	if err != nil {
+		return nil, err
+	}
+	items, err := GetOne[[]Enumeration](ac, u, kind)
+	if err != nil {
+		return nil, err
+	}
+	return *items, nil
+}
+
+// Filter the active items of enumeration.
+func ActiveOnly(items []Enumeration) []Enumeration {
+	active := []Enumeration{}
+	for _, e := range items {
+		if e.Active {
+			active = append(active, e)
+		}
+	}
+	return active
+}
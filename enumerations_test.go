@@ -0,0 +1,46 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetEnumeration(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/enumerations/issue_priorities.json":
+			w.Write([]byte(`{"issue_priorities": [
+				{"id": 1, "name": "Low", "is_default": false, "active": true},
+				{"id": 2, "name": "Normal", "is_default": true, "active": true},
+				{"id": 3, "name": "Obsolete", "is_default": false, "active": false}]}`))
+		case "/enumerations/document_categories.json":
+			w.Write([]byte(`{"document_categories": [{"id": 7, "name": "Manual", "active": true}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	priorities, err := ac.GetEnumeration(EnumIssuePriorities)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(priorities) != 3 || !priorities[1].IsDefault {
+		t.Errorf("unexpected priorities: %+v", priorities)
+	}
+	if active := ActiveOnly(priorities); len(active) != 2 || active[1].Name != "Normal" {
+		t.Errorf("unexpected active priorities: %+v", active)
+	}
+
+	categories, err := ac.GetEnumeration(EnumDocumentCategories)
+	if err != nil || len(categories) != 1 || categories[0].Name != "Manual" {
+		t.Errorf("unexpected categories: %+v, %v", categories, err)
+	}
+
+	if _, err = ac.GetEnumeration(EnumTimeEntryActivities); err == nil {
+		t.Error("expected not found error")
+	}
+}
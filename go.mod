@@ -1,6 +1,6 @@
 module github.com/1buran/redmine
 
-go 1.22.0
+go 1.23.0
 
 retract (
 	v0.0.1-alpha
@@ -0,0 +1,334 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Associated data of issue, any other value is passed to Redmine as is.
+const (
+	IncludeJournals    = "journals"
+	IncludeAttachments = "attachments"
+	IncludeRelations   = "relations"
+	IncludeChildren    = "children"
+	IncludeWatchers    = "watchers"
+)
+
+// Issues filtration by list of issue ids and date ranges.
+type IssuesFilter struct {
+	// Fetch only these issues (both open and closed), if the list is larger than
+	// [MaxIssueIDs], it is split into multiple requests automatically.
+	IssueIDs []int
+
+	ProjectID      int
+	FixedVersionID int
+	TrackerID      int
+	// Status id or one of the special values: "open" (Redmine default), "closed", "*" (any).
+	StatusID string
+	// Assignee user (or group) id or "me" for the user of API token.
+	AssignedToID string
+
+	CreatedOn DateRange
+	UpdatedOn DateRange
+	ClosedOn  DateRange
+
+	// Associated data included to every scrolled issue: [IncludeJournals],
+	// [IncludeAttachments], [IncludeRelations], [IncludeWatchers].
+	// Opt-in only: it substantially increases the size of responses and may hit
+	// the limits of server, use it with care for large datasets.
+	Include []string
+}
+
+// A range of dates or datetimes (if WithTime is set) for filtration, zero bound means
+// the range is open from that side, both zero bounds mean no filtration.
+type DateRange struct {
+	From     time.Time
+	To       time.Time
+	WithTime bool
+}
+
+// Format bound of range: date (yyyy-mm-dd) or UTC datetime (yyyy-mm-ddThh:mm:ssZ).
+func (r DateRange) format(t time.Time) string {
+	if r.WithTime {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return Date{t}.String()
+}
+
+// Encode range using Redmine operator syntax: "><from|to", ">=from" or "<=to".
+func (r DateRange) encode() string {
+	switch {
+	case !r.From.IsZero() && !r.To.IsZero():
+		return "><" + r.format(r.From) + "|" + r.format(r.To)
+	case !r.From.IsZero():
+		return ">=" + r.format(r.From)
+	case !r.To.IsZero():
+		return "<=" + r.format(r.To)
+	}
+	return ""
+}
+
+// Encode the set fields of filter to query params.
+func (f IssuesFilter) encode(v *url.Values) {
+	if len(f.IssueIDs) > 0 {
+		ids := make([]string, len(f.IssueIDs))
+		for i, id := range f.IssueIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		v.Set("issue_id", strings.Join(ids, ","))
+		v.Set("status_id", "*") // otherwise closed issues are skipped
+	}
+	if f.ProjectID != 0 {
+		v.Set("project_id", strconv.Itoa(f.ProjectID))
+	}
+	if f.FixedVersionID != 0 {
+		v.Set("fixed_version_id", strconv.Itoa(f.FixedVersionID))
+	}
+	if f.TrackerID != 0 {
+		v.Set("tracker_id", strconv.Itoa(f.TrackerID))
+	}
+	if f.StatusID != "" {
+		v.Set("status_id", f.StatusID)
+	}
+	if f.AssignedToID != "" {
+		v.Set("assigned_to_id", f.AssignedToID)
+	}
+	for param, r := range map[string]DateRange{
+		"created_on": f.CreatedOn, "updated_on": f.UpdatedOn, "closed_on": f.ClosedOn} {
+		if s := r.encode(); s != "" {
+			v.Set(param, s)
+		}
+	}
+}
+
+// Construct the URL of issues list in Redmine web interface with the filter pre-applied,
+// e.g. to print a browser link reproducing the query made via API. The web interface
+// accepts the same short operator syntax, so both views list the same issues.
+func (ac *ApiClient) IssuesWebURL(f IssuesFilter) string {
+	v := url.Values{}
+	v.Set("set_filter", "1")
+	f.encode(&v)
+	return strings.TrimSuffix(ac.Url, "/") + "/issues?" + v.Encode()
+}
+
+// Scroll issues like [Scroll], but with the given filter instead of the filter of client.
+func ScrollIssues(ac *ApiClient, f IssuesFilter) (<-chan Issue, <-chan error) {
+	return Scroll[Issue](ac.withIssuesFilter(f))
+}
+
+// Construct the URL of single issue with optional associated data, e.g. include=journals.
+func (ac *ApiClient) IssueUrl(id int, include ...string) (string, error) {
+	v := url.Values{}
+	setInclude(&v, ac.includes("issues", include...))
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/issues/%d.json", id), &v, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get a single issue by id, include is a list of associated data to fetch along
+// with the issue: children, attachments, relations, changesets, journals, watchers etc.
+func (ac *ApiClient) GetIssue(id int, include ...string) (*Issue, error) {
+	u, err := ac.IssueUrl(id, include...)
+	if err != nil {
+		return nil, err
+	}
+	return GetOne[Issue](ac, u, "issue")
+}
+
+var (
+	AncestryCycleError  = errors.New("issue ancestry has a cycle or is too deep")
+	EmptyProjectError   = errors.New("project id must be set")
+	EmptySubjectError   = errors.New("subject must be set")
+	DueDateError        = errors.New("due date must be greater than or equal to start date")
+	DoneRatioRangeError = errors.New("done ratio must be within 0-100")
+	ParentNotFoundError = errors.New("parent issue not found")
+	ParentProjectError  = errors.New("parent issue belongs to another project")
+	WatcherIDError      = errors.New("watcher user ids must be positive")
+)
+
+// Payload for creation or update of issue, see also [IssueUpdateBuilder] for partial update
+// which clears fields or sets them to zero values.
+type CreateIssuePayload struct {
+	ProjectID      int     `json:"project_id,omitempty"`
+	TrackerID      int     `json:"tracker_id,omitempty"`
+	StatusID       int     `json:"status_id,omitempty"`
+	PriorityID     int     `json:"priority_id,omitempty"`
+	Subject        string  `json:"subject,omitempty"`
+	Description    string  `json:"description,omitempty"`
+	CategoryID     int     `json:"category_id,omitempty"`
+	FixedVersionID int     `json:"fixed_version_id,omitempty"`
+	AssignedToID   int     `json:"assigned_to_id,omitempty"`
+	ParentID       int     `json:"parent_issue_id,omitempty"`
+	Watchers       []int   `json:"watcher_user_ids,omitempty"`
+	IsPrivate      bool    `json:"is_private,omitempty"`
+	EstimatedHours float32 `json:"estimated_hours,omitempty"`
+	// Pointer allows to set 0% explicitly, nil means the field is omitted.
+	DoneRatio *int `json:"done_ratio,omitempty"`
+	// Zero dates are omitted, see [CreateIssuePayload.MarshalJSON].
+	StartDate Date `json:"start_date,omitempty"`
+	DueDate   Date `json:"due_date,omitempty"`
+	// Values of custom fields by id, the value is string or list of strings
+	// for multi-value fields, see [CustomField].
+	CustomFields []CustomField `json:"custom_fields,omitempty"`
+}
+
+// Marshal the payload omitting zero dates: omitempty has no effect on struct types,
+// so the zero date would be sent as "0001-01-01", the duplicate watchers are dropped.
+func (p CreateIssuePayload) MarshalJSON() ([]byte, error) {
+	type plain CreateIssuePayload
+	aux := struct {
+		plain
+		StartDate *Date `json:"start_date,omitempty"`
+		DueDate   *Date `json:"due_date,omitempty"`
+	}{plain: plain(p)}
+	// duplicate watchers are harmless for Redmine, but make the payload confusing
+	aux.Watchers = nil
+	for _, id := range p.Watchers {
+		if !slices.Contains(aux.Watchers, id) {
+			aux.Watchers = append(aux.Watchers, id)
+		}
+	}
+	if !p.StartDate.IsZero() {
+		aux.StartDate = &p.StartDate
+	}
+	if !p.DueDate.IsZero() {
+		aux.DueDate = &p.DueDate
+	}
+	return json.Marshal(aux)
+}
+
+// JSON wrapper of issue payload expected by Redmine: {"issue": {...}}.
+type PostDataIssue struct {
+	Issue CreateIssuePayload `json:"issue"`
+}
+
+// Update issue request JSON envelope: only the set (non-zero) fields of payload are sent,
+// so the partial update doesn't clobber the other fields of issue.
+type PutDataIssue struct {
+	Issue CreateIssuePayload `json:"issue"`
+}
+
+// Validate the issue payload before sending it to Redmine.
+func (p CreateIssuePayload) Validate() error {
+	return allErrors(
+		requireNonZeroInt(EmptyProjectError, "project_id", p.ProjectID),
+		requireNonEmpty(EmptySubjectError, "subject", p.Subject),
+		p.ValidateUpdate(),
+	)
+}
+
+// Validate the issue payload of partial update: the same as [CreateIssuePayload.Validate],
+// but no field is required.
+func (p CreateIssuePayload) ValidateUpdate() error {
+	var doneRatio error
+	if p.DoneRatio != nil {
+		doneRatio = inRange(DoneRatioRangeError, "done_ratio", *p.DoneRatio, 0, 100)
+	}
+	return allErrors(
+		doneRatio,
+		check(p.StartDate.IsZero() || p.DueDate.IsZero() || !p.DueDate.Before(p.StartDate),
+			DueDateError, "due_date"),
+		// Redmine silently ignores invalid ids
+		check(!slices.ContainsFunc(p.Watchers, func(id int) bool { return id <= 0 }),
+			WatcherIDError, "watcher_user_ids"),
+	)
+}
+
+// Update issue partially: only the set (non-zero) fields of payload are sent, see
+// [CreateIssuePayload.AsUpdate], use [IssuesService.Update] with [IssueUpdateBuilder]
+// to clear fields or set them to zero values, e.g. is_private=false. Redmine replies
+// 204 No Content (some versions 200 OK) on success, see [ApiClient.Update].
+func (ac *ApiClient) UpdateIssue(id int, payload CreateIssuePayload) error {
+	return ac.Issues().Update(id, payload.AsUpdate())
+}
+
+// Delete issue, the missing issue is [NotFoundError].
+func (ac *ApiClient) DeleteIssue(id int) error {
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/issues/%d.json", id), &url.Values{}, 0)
+	if err != nil {
+		return errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	_, err = ac.Delete(u)
+	return err
+}
+
+// Create issue: validate the payload and send it to Redmine.
+//
+// If ParentID is set, the parent issue is looked up to make sure it exists and belongs
+// to the same project ([ParentNotFoundError], [ParentProjectError]). The check is
+// best-effort: other lookup failures are ignored and left to the validation of Redmine.
+func (ac *ApiClient) CreateIssue(p CreateIssuePayload) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	if p.ParentID != 0 {
+		parent, err := ac.GetIssue(p.ParentID)
+		switch {
+		case errors.Is(err, NotFoundError):
+			return errors.Join(ValidationError, ParentNotFoundError,
+				fmt.Errorf("parent issue id %d", p.ParentID))
+		case err == nil && parent.Project.Id != p.ProjectID:
+			return errors.Join(ValidationError, ParentProjectError,
+				fmt.Errorf("parent issue %d is in project %d, not %d",
+					p.ParentID, parent.Project.Id, p.ProjectID))
+		}
+	}
+
+	u, err := BuildApiUrl(ac.Url, IssuesApiEndpoint, &url.Values{}, 0)
+	if err != nil {
+		return errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return CreateEntity(ac, u, "issue", p)
+}
+
+// Compute the average done ratio of issues for rollup dashboards, 0 for empty list.
+// The done ratio of issue with subtasks may be computed by Redmine from its children,
+// depending on the instance settings.
+func AverageDoneRatio(issues []Issue) float64 {
+	if len(issues) == 0 {
+		return 0
+	}
+	var sum int
+	for _, i := range issues {
+		sum += i.DoneRatio
+	}
+	return float64(sum) / float64(len(issues))
+}
+
+// The max depth of issue ancestry, deeper chains are treated as malformed data.
+const MaxAncestryDepth = 100
+
+// Get the parent chain of issue up to the root, e.g. for breadcrumbs: the ancestors are
+// returned root-first, the issue itself is not included. The chain with cycle
+// or deeper than [MaxAncestryDepth] is reported as [AncestryCycleError].
+func (ac *ApiClient) GetIssueAncestors(issueID int) ([]Issue, error) {
+	issue, err := ac.GetIssue(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[int]bool{issue.Id: true}
+	var ancestors []Issue
+	for issue.Parent != nil {
+		id := issue.Parent.Id
+		if visited[id] || len(ancestors) >= MaxAncestryDepth {
+			return nil, errors.Join(AncestryCycleError, fmt.Errorf("issue %d, parent %d", issue.Id, id))
+		}
+		visited[id] = true
+		if issue, err = ac.GetIssue(id); err != nil {
+			return nil, err
+		}
+		ancestors = append(ancestors, *issue)
+	}
+	slices.Reverse(ancestors)
+	return ancestors, nil
+}
@@ -0,0 +1,760 @@
+package redmine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultIncludes(t *testing.T) {
+	ac := CreateApiConfig("https://example.com")
+	ac.DefaultIncludes = map[string][]string{"issues": {"journals", "attachments"}}
+
+	u, err := ac.IssueUrl(1, "relations", "journals")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pu, _ := url.Parse(u)
+	if inc := pu.Query().Get("include"); inc != "journals,attachments,relations" {
+		t.Errorf("expected merged include, got: %s", inc)
+	}
+
+	u, err = ApiEndpointURL[Issue](ac, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pu, _ = url.Parse(u)
+	if inc := pu.Query().Get("include"); inc != "journals,attachments" {
+		t.Errorf("expected default include, got: %s", inc)
+	}
+
+	u, _ = ApiEndpointURL[Project](ac, 0)
+	if pu, _ = url.Parse(u); pu.Query().Has("include") {
+		t.Errorf("unexpected include for projects: %s", u)
+	}
+}
+
+func TestIssueSpentHours(t *testing.T) {
+	data := `{"issues": [
+		{"id": 1, "subject": "with", "spent_hours": 1.5, "total_spent_hours": 4.25},
+		{"id": 2, "subject": "without"}
+	], "offset": 0, "limit": 25, "total_count": 2}`
+	r, err := DecodeResp[Issue](io.NopCloser(strings.NewReader(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if i := r.Items[0]; i.SpentHours != 1.5 || i.TotalSpentHours != 4.25 {
+		t.Errorf("expected 1.5/4.25 spent hours, got: %.2f/%.2f", i.SpentHours, i.TotalSpentHours)
+	}
+	if i := r.Items[1]; i.SpentHours != 0 || i.TotalSpentHours != 0 {
+		t.Errorf("expected zero spent hours, got: %.2f/%.2f", i.SpentHours, i.TotalSpentHours)
+	}
+}
+
+func TestScrollIssueIDs(t *testing.T) {
+	var requests int
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		if q.Get("status_id") != "*" {
+			t.Errorf("expected status_id=*, got: %s", r.URL.RawQuery)
+		}
+		ids := strings.Split(q.Get("issue_id"), ",")
+		if len(ids) > MaxIssueIDs {
+			t.Errorf("expected at most %d ids, got: %d", MaxIssueIDs, len(ids))
+		}
+		items := make([]string, len(ids))
+		for i, id := range ids {
+			items[i] = fmt.Sprintf(`{"id": %s, "subject": "Subject %s"}`, id, id)
+		}
+		fmt.Fprintf(w, `{"issues": [%s], "offset": 0, "limit": %d, "total_count": %d}`,
+			strings.Join(items, ","), len(ids), len(ids))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	for i := 1; i <= 250; i++ {
+		ac.IssueIDs = append(ac.IssueIDs, i)
+	}
+
+	dataChan, _ := Scroll[Issue](ac)
+	i := 1
+	for issue := range dataChan {
+		if issue.Id != i {
+			t.Errorf("expected %d, got %d", i, issue.Id)
+		}
+		i++
+	}
+	if i-1 != 250 {
+		t.Errorf("expected 250 items, got: %d", i-1)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got: %d", requests)
+	}
+}
+
+func TestGetAllIssueIDs(t *testing.T) {
+	var requests int
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ids := strings.Split(r.URL.Query().Get("issue_id"), ",")
+		if len(ids) > MaxIssueIDs {
+			t.Errorf("expected at most %d ids, got: %d", MaxIssueIDs, len(ids))
+		}
+		items := make([]string, len(ids))
+		for i, id := range ids {
+			items[i] = fmt.Sprintf(`{"id": %s}`, id)
+		}
+		fmt.Fprintf(w, `{"issues": [%s], "offset": 0, "limit": %d, "total_count": %d}`,
+			strings.Join(items, ","), len(ids), len(ids))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	for i := 1; i <= 150; i++ {
+		ac.IssueIDs = append(ac.IssueIDs, i)
+	}
+
+	issues, err := GetAll[Issue](ac)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(issues) != 150 || issues[149].Id != 150 {
+		t.Errorf("expected 150 issues, got: %d", len(issues))
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got: %d", requests)
+	}
+}
+
+func TestIssueIDsBatchedEverywhere(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		ids := strings.Split(q.Get("issue_id"), ",")
+		if len(ids) > MaxIssueIDs {
+			t.Errorf("expected at most %d ids, got: %d", MaxIssueIDs, len(ids))
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit := 25
+		if q.Has("limit") {
+			limit, _ = strconv.Atoi(q.Get("limit"))
+		}
+		if page, _ := strconv.Atoi(q.Get("page")); page > 1 {
+			offset = (page - 1) * limit
+		}
+		var items []string
+		for i := offset; i < min(offset+limit, len(ids)); i++ {
+			items = append(items, fmt.Sprintf(`{"id": %s}`, ids[i]))
+		}
+		fmt.Fprintf(w, `{"issues": [%s], "offset": %d, "limit": %d, "total_count": %d}`,
+			strings.Join(items, ","), offset, limit, len(ids))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	for i := 1; i <= 250; i++ {
+		ac.IssueIDs = append(ac.IssueIDs, i)
+	}
+
+	var ids []int
+	for issue, err := range Items[Issue](ac) {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		ids = append(ids, issue.Id)
+	}
+	if len(ids) != 250 || ids[0] != 1 || ids[99] != 100 || ids[100] != 101 || ids[249] != 250 {
+		t.Errorf("expected issues 1-250 in order, got %d: %v", len(ids), ids)
+	}
+
+	if n, err := Count[Issue](ac); n != 250 || err != nil {
+		t.Errorf("expected count 250, got: %d, %v", n, err)
+	}
+
+	// the page crossing the boundary of batches
+	r, err := GetOffset[Issue](ac, 90)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(r.Items) != 25 || r.Items[0].Id != 91 || r.Items[24].Id != 115 || r.Total != 250 {
+		t.Errorf("expected issues 91-115 of 250, got: %+v", r)
+	}
+	r, err = Get[Issue](ac, 9)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(r.Items) != 25 || r.Items[0].Id != 201 || r.Total != 250 {
+		t.Errorf("expected issues 201-225 of 250, got: %+v", r)
+	}
+
+	dataChan, errChan := ScrollParallel[Issue](ac, ParallelOptions{Ordered: true})
+	go func() {
+		for err := range errChan {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}()
+	ids = nil
+	for issue := range dataChan {
+		ids = append(ids, issue.Id)
+	}
+	if len(ids) != 250 || ids[249] != 250 {
+		t.Errorf("expected 250 issues, got: %d", len(ids))
+	}
+}
+
+func TestCreateIssuePayloadDoneRatio(t *testing.T) {
+	ratio := func(r int) *int { return &r }
+
+	b, _ := json.Marshal(PostDataIssue{CreateIssuePayload{ProjectID: 1, DoneRatio: ratio(0)}})
+	if !strings.Contains(string(b), `"done_ratio":0`) {
+		t.Errorf("expected explicit zero done_ratio, got: %s", b)
+	}
+	b, _ = json.Marshal(PostDataIssue{CreateIssuePayload{ProjectID: 1}})
+	if strings.Contains(string(b), `done_ratio`) {
+		t.Errorf("expected omitted done_ratio, got: %s", b)
+	}
+
+	for r, valid := range map[int]bool{-1: false, 0: true, 50: true, 100: true, 101: false} {
+		err := CreateIssuePayload{ProjectID: 1, Subject: "subj", DoneRatio: ratio(r)}.Validate()
+		if valid && err != nil {
+			t.Errorf("%d: unexpected error: %s", r, err)
+		}
+		if !valid && !errors.Is(err, DoneRatioRangeError) {
+			t.Errorf("%d: expected DoneRatioRangeError, got: %s", r, err)
+		}
+	}
+
+	if err := (CreateIssuePayload{}).Validate(); !errors.Is(err, EmptyProjectError) {
+		t.Errorf("expected EmptyProjectError, got: %s", err)
+	}
+}
+
+func TestCreateIssuePayloadSubject(t *testing.T) {
+	b, _ := json.Marshal(PostDataIssue{CreateIssuePayload{ProjectID: 1, Subject: "Broken build"}})
+	if !strings.Contains(string(b), `"subject":"Broken build"`) {
+		t.Errorf("expected subject, got: %s", b)
+	}
+	for _, subject := range []string{"", "   "} {
+		err := CreateIssuePayload{ProjectID: 1, Subject: subject}.Validate()
+		if !errors.Is(err, EmptySubjectError) || !errors.Is(err, ValidationError) {
+			t.Errorf("%q: expected EmptySubjectError, got: %v", subject, err)
+		}
+	}
+	// the subject is not required by partial update
+	if err := (CreateIssuePayload{StatusID: 5}).ValidateUpdate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestIssuesFilterDateRanges(t *testing.T) {
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC)
+
+	ac := CreateApiConfig("https://example.com")
+	ac.CreatedOn = DateRange{From: start, To: end}
+	ac.UpdatedOn = DateRange{From: at, WithTime: true}
+	ac.ClosedOn = DateRange{To: end}
+
+	u, err := ApiEndpointURL[Issue](ac, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pu, _ := url.Parse(u)
+	q := pu.Query()
+	expected := map[string]string{
+		"created_on": "><2024-03-01|2024-03-31",
+		"updated_on": ">=2024-03-01T10:30:00Z",
+		"closed_on":  "<=2024-03-31",
+	}
+	for k, v := range expected {
+		if q.Get(k) != v {
+			t.Errorf("expected %s=%s, got: %s", k, v, q.Get(k))
+		}
+	}
+
+	u, _ = ApiEndpointURL[Issue](CreateApiConfig("https://example.com"), 0)
+	if strings.Contains(u, "?") {
+		t.Errorf("expected url without filter, got: %s", u)
+	}
+}
+
+func TestScrollIssuesInclude(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if inc := r.URL.Query().Get("include"); inc != "relations,attachments" {
+			t.Errorf("expected include=relations,attachments, got: %s", inc)
+		}
+		w.Write([]byte(`{"issues": [
+			{"id": 1, "subject": "Subject 1",
+			 "relations": [{"id": 5, "issue_id": 1, "issue_to_id": 2, "relation_type": "blocks", "delay": null}],
+			 "attachments": [{"id": 7, "filename": "a.png", "filesize": 1024, "content_type": "image/png",
+			   "created_on": "2024-03-01T10:00:00Z"}]},
+			{"id": 2, "subject": "Subject 2"}
+		], "offset": 0, "limit": 25, "total_count": 2}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.IssuesFilter.Include = []string{"relations", "attachments"}
+	dataChan, _ := Scroll[Issue](ac)
+
+	var issues []Issue
+	for i := range dataChan {
+		issues = append(issues, i)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got: %d", len(issues))
+	}
+	if r := issues[0].Relations; len(r) != 1 || r[0].RelationType != "blocks" || r[0].IssueToID != 2 {
+		t.Errorf("unexpected relations: %+v", r)
+	}
+	if a := issues[0].Attachments; len(a) != 1 || a[0].Filename != "a.png" || a[0].Filesize != 1024 {
+		t.Errorf("unexpected attachments: %+v", a)
+	}
+	if issues[1].Relations != nil || issues[1].Attachments != nil {
+		t.Errorf("expected no associated data, got: %+v", issues[1])
+	}
+}
+
+func TestAverageDoneRatio(t *testing.T) {
+	if r := AverageDoneRatio(nil); r != 0 {
+		t.Errorf("expected 0, got: %f", r)
+	}
+	issues := []Issue{{DoneRatio: 0}, {DoneRatio: 50}, {DoneRatio: 100}, {DoneRatio: 30}}
+	if r := AverageDoneRatio(issues); r != 45 {
+		t.Errorf("expected 45, got: %f", r)
+	}
+}
+
+func TestGetIssueAncestors(t *testing.T) {
+	// 4 -> 3 -> 2 -> 1 is a valid chain, 6 -> 5 -> 6 is a cycle
+	parents := map[int]int{4: 3, 3: 2, 2: 1, 6: 5, 5: 6}
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/issues/%d.json", &id)
+		if parent, ok := parents[id]; ok {
+			fmt.Fprintf(w, `{"issue": {"id": %d, "parent": {"id": %d}}}`, id, parent)
+			return
+		}
+		fmt.Fprintf(w, `{"issue": {"id": %d}}`, id)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	ancestors, err := ac.GetIssueAncestors(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var ids []int
+	for _, i := range ancestors {
+		ids = append(ids, i.Id)
+	}
+	if !slices.Equal(ids, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got: %v", ids)
+	}
+
+	if ancestors, err = ac.GetIssueAncestors(1); err != nil || len(ancestors) != 0 {
+		t.Errorf("expected no ancestors, got: %v, %v", ancestors, err)
+	}
+
+	if _, err = ac.GetIssueAncestors(6); !errors.Is(err, AncestryCycleError) {
+		t.Errorf("expected AncestryCycleError, got: %s", err)
+	}
+}
+
+func TestIssueStartDueDates(t *testing.T) {
+	var issue Issue
+	data := `{"id": 1, "start_date": "2024-03-01", "due_date": null}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if issue.StartDate.String() != "2024-03-01" || !issue.DueDate.IsZero() {
+		t.Errorf("unexpected dates: %s, %s", issue.StartDate, issue.DueDate)
+	}
+
+	p := CreateIssuePayload{ProjectID: 1, Subject: "subj"}
+	b, _ := json.Marshal(PostDataIssue{p})
+	if strings.Contains(string(b), "_date") {
+		t.Errorf("expected omitted dates, got: %s", b)
+	}
+
+	p.StartDate, _ = DateFromString("2024-03-10")
+	p.DueDate, _ = DateFromString("2024-03-31")
+	b, _ = json.Marshal(PostDataIssue{p})
+	if !strings.Contains(string(b), `"start_date":"2024-03-10","due_date":"2024-03-31"`) ||
+		!strings.Contains(string(b), `"subject":"subj"`) {
+		t.Errorf("unexpected JSON: %s", b)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	p.DueDate, _ = DateFromString("2024-03-01")
+	if err := p.Validate(); !errors.Is(err, DueDateError) || !errors.Is(err, ValidationError) {
+		t.Errorf("expected DueDateError, got: %s", err)
+	}
+}
+
+func TestIssuesWebURL(t *testing.T) {
+	ac := CreateApiConfig("https://redmine.example.com/")
+	f := IssuesFilter{
+		IssueIDs:  []int{1, 2},
+		CreatedOn: DateRange{From: time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)},
+	}
+	expected := "https://redmine.example.com/issues?" +
+		"created_on=%3E%3D2024-03-01&issue_id=1%2C2&set_filter=1&status_id=%2A"
+	if u := ac.IssuesWebURL(f); u != expected {
+		t.Errorf("expected %s, got %s", expected, u)
+	}
+}
+
+func TestCreateIssueParentValidation(t *testing.T) {
+	var created int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/issues/10.json":
+			w.Write([]byte(`{"issue": {"id": 10, "project": {"id": 1, "name": "One"}}}`))
+		case r.Method == http.MethodPost && r.URL.Path == IssuesApiEndpoint:
+			created++
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"issue": {"id": 11}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	if err := ac.CreateIssue(CreateIssuePayload{ProjectID: 1, Subject: "sub", ParentID: 10}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	err := ac.CreateIssue(CreateIssuePayload{ProjectID: 2, Subject: "sub", ParentID: 10})
+	if !errors.Is(err, ParentProjectError) || !errors.Is(err, ValidationError) {
+		t.Errorf("expected ParentProjectError, got: %s", err)
+	}
+	err = ac.CreateIssue(CreateIssuePayload{ProjectID: 1, Subject: "sub", ParentID: 12})
+	if !errors.Is(err, ParentNotFoundError) {
+		t.Errorf("expected ParentNotFoundError, got: %s", err)
+	}
+	if created != 1 {
+		t.Errorf("expected 1 created issue, got: %d", created)
+	}
+}
+
+func TestCreateIssuePayloadWatchers(t *testing.T) {
+	p := CreateIssuePayload{ProjectID: 1, Subject: "subj", Watchers: []int{3, 5, 3, 7, 5}}
+	if err := p.Validate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	b, _ := json.Marshal(PostDataIssue{p})
+	if !strings.Contains(string(b), `"watcher_user_ids":[3,5,7]`) {
+		t.Errorf("expected deduplicated watchers, got: %s", b)
+	}
+	if len(p.Watchers) != 5 {
+		t.Errorf("expected the payload to be untouched, got: %v", p.Watchers)
+	}
+
+	for _, watchers := range [][]int{{3, 0}, {-1}} {
+		p.Watchers = watchers
+		if err := p.Validate(); !errors.Is(err, WatcherIDError) || !errors.Is(err, ValidationError) {
+			t.Errorf("%v: expected WatcherIDError, got: %s", watchers, err)
+		}
+	}
+}
+
+func TestGetIssue(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/issues/42.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"issue": {"id": 42, "subject": "Subject 42", "description": "Issue 42 Description",
+			"project": {"id": 3, "name": "Project3"}, "status": {"id": 1, "name": "New"}}}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	u, err := ac.IssueUrl(42)
+	if err != nil || u != testServer.URL+"/issues/42.json" {
+		t.Errorf("unexpected issue url: %s, %v", u, err)
+	}
+
+	issue, err := ac.GetIssue(42)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if issue.Id != 42 || issue.Desc != "Issue 42 Description" || issue.Project.Id != 3 ||
+		issue.Project.Name != "Project3" || issue.Status.Name != "New" {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+
+	_, err = ac.GetIssue(43)
+	if !errors.Is(err, NotFoundError) || !errors.Is(err, HttpError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+}
+
+func TestIssueLegacyTimestamps(t *testing.T) {
+	var issue Issue
+	data := `{"id": 1,
+		"journals": [{"id": 1, "notes": "Note", "created_on": "Sat Sep 29 12:03:04 +0200 2007"}],
+		"attachments": [{"id": 2, "filename": "a.txt", "created_on": "Sat Sep 29 12:03:04 +0200 2007"}]}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := time.Date(2007, 9, 29, 10, 3, 4, 0, time.UTC)
+	if !issue.Journals[0].CreatedOn.Equal(expected) || !issue.Attachments[0].CreatedOn.Equal(expected) {
+		t.Errorf("expected %s, got: %s, %s", expected, issue.Journals[0].CreatedOn, issue.Attachments[0].CreatedOn)
+	}
+}
+
+func TestPutDataIssue(t *testing.T) {
+	var body string
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/issues/156.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	p := CreateIssuePayload{StatusID: 5}
+	if err := p.ValidateUpdate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	data, _ := json.Marshal(PutDataIssue{p})
+	if err := ac.Update(testServer.URL+"/issues/156.json", bytes.NewReader(data)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if body != `{"issue":{"status_id":5}}` {
+		t.Errorf("expected only status_id, got: %s", body)
+	}
+
+	ratio := 101
+	if err := (CreateIssuePayload{DoneRatio: &ratio}).ValidateUpdate(); !errors.Is(err, DoneRatioRangeError) {
+		t.Errorf("expected DoneRatioRangeError, got: %s", err)
+	}
+}
+
+func TestDeleteIssue(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Redmine-API-Key") != "test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodDelete || r.URL.Path != "/issues/156.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+	ac.Token = "test-token"
+
+	if err := ac.DeleteIssue(156); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := ac.DeleteIssue(157); !errors.Is(err, NotFoundError) || !errors.Is(err, HttpError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+	ac.Token = "wrong"
+	if err := ac.DeleteIssue(156); !errors.Is(err, AuthError) {
+		t.Errorf("expected AuthError, got: %s", err)
+	}
+}
+
+func TestUpdateIssue(t *testing.T) {
+	var body string
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		switch r.URL.Path {
+		case "/issues/156.json":
+			w.WriteHeader(http.StatusNoContent)
+		case "/issues/157.json":
+			w.Write([]byte(`{}`)) // older versions reply 200 OK
+		case "/issues/158.json":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors": ["Status is invalid"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	if err := ac.UpdateIssue(156, CreateIssuePayload{StatusID: 5, AssignedToID: 3}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if body != `{"issue":{"assigned_to_id":3,"status_id":5}}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+	ratio := 0
+	if err := ac.UpdateIssue(156, CreateIssuePayload{Subject: "New", DoneRatio: &ratio}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if body != `{"issue":{"done_ratio":0,"subject":"New"}}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	// zero values and clearing are sent explicitly by builder
+	b := NewIssueUpdate().SetPrivate(false).SetDoneRatio(0).SetAssignee(0).SetDueDate(Date{})
+	if err := ac.Issues().Update(156, b); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if body != `{"issue":{"assigned_to_id":"","done_ratio":0,"due_date":"","is_private":false}}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	if err := ac.UpdateIssue(157, CreateIssuePayload{StatusID: 5}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	err := ac.UpdateIssue(158, CreateIssuePayload{StatusID: 99})
+	if !errors.Is(err, HttpError) || !strings.Contains(err.Error(), "Status is invalid") {
+		t.Errorf("expected HttpError with Redmine message, got: %v", err)
+	}
+	if err := ac.UpdateIssue(159, CreateIssuePayload{StatusID: 5}); !errors.Is(err, NotFoundError) {
+		t.Errorf("expected NotFoundError, got: %v", err)
+	}
+
+	body = ""
+	start, _ := DateFromString("2024-03-10")
+	due, _ := DateFromString("2024-03-01")
+	ratio = -1
+	err = ac.UpdateIssue(156, CreateIssuePayload{DoneRatio: &ratio, StartDate: start, DueDate: due})
+	if !errors.Is(err, DoneRatioRangeError) || !errors.Is(err, DueDateError) {
+		t.Errorf("expected DoneRatioRangeError and DueDateError, got: %v", err)
+	}
+	if body != "" {
+		t.Errorf("expected no request for invalid update, got: %s", body)
+	}
+	if err := ac.UpdateIssue(156, CreateIssuePayload{}); !errors.Is(err, EmptyUpdateError) {
+		t.Errorf("expected EmptyUpdateError, got: %v", err)
+	}
+}
+
+func TestScrollIssues(t *testing.T) {
+	var query url.Values
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"issues": [{"id": 1, "subject": "Bug"}], "total_count": 1, "offset": 0, "limit": 25}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	f := IssuesFilter{ProjectID: 2, TrackerID: 3, StatusID: "open", AssignedToID: "me"}
+	dataChan, errChan := ScrollIssues(ac, f)
+	go func() {
+		for err := range errChan {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}()
+	var issues []Issue
+	for i := range dataChan {
+		issues = append(issues, i)
+	}
+	if len(issues) != 1 {
+		t.Errorf("expected 1 issue, got: %v", issues)
+	}
+	for param, want := range map[string]string{
+		"project_id": "2", "tracker_id": "3", "status_id": "open", "assigned_to_id": "me"} {
+		if got := query.Get(param); got != want {
+			t.Errorf("expected %s=%s, got: %s", param, want, got)
+		}
+	}
+	if query.Has("fixed_version_id") || query.Has("created_on") {
+		t.Errorf("expected zero fields omitted, got: %s", query.Encode())
+	}
+	if ac.IssuesFilter.ProjectID != 0 {
+		t.Errorf("expected filter of client untouched, got: %+v", ac.IssuesFilter)
+	}
+}
+
+func TestIncludePassthrough(t *testing.T) {
+	ac := CreateApiConfig("https://example.com")
+	ac.IssuesFilter.Include = []string{IncludeJournals, "custom_plugin_data"}
+	ac.DefaultIncludes = map[string][]string{"projects": {"trackers", "enabled_modules"}}
+
+	u, _ := ApiEndpointURL[Issue](ac, 0)
+	pu, _ := url.Parse(u)
+	if inc := pu.Query().Get("include"); inc != "journals,custom_plugin_data" {
+		t.Errorf("expected unknown include passed through, got: %s", inc)
+	}
+
+	u, _ = ApiEndpointURL[Project](ac, 0)
+	pu, _ = url.Parse(u)
+	if inc := pu.Query().Get("include"); inc != "trackers,enabled_modules" {
+		t.Errorf("expected include of projects, got: %s", inc)
+	}
+}
+
+func TestIssueAssociations(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include") == "" {
+			w.Write([]byte(`{"issues": [{"id": 1, "subject": "Lean"}], "offset": 0, "limit": 25, "total_count": 1}`))
+			return
+		}
+		w.Write([]byte(`{"issues": [{"id": 1, "subject": "Full",
+			"journals": [{"id": 3, "notes": "Looks good", "user": {"id": 2, "name": "Jane"}}],
+			"attachments": [{"id": 7, "filename": "log.txt", "filesize": 10}],
+			"watchers": [{"id": 2, "name": "Jane"}, {"id": 5, "name": "Bob"}]}],
+			"offset": 0, "limit": 25, "total_count": 1}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	r, err := Get[Issue](ac, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if i := r.Items[0]; i.Journals != nil || i.Attachments != nil || i.Watchers != nil {
+		t.Errorf("expected lean issue, got: %+v", i)
+	}
+
+	ac.IssuesFilter.Include = []string{IncludeJournals, IncludeAttachments, IncludeWatchers}
+	if r, err = Get[Issue](ac, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	i := r.Items[0]
+	if len(i.Journals) != 1 || i.Journals[0].Notes != "Looks good" {
+		t.Errorf("unexpected journals: %+v", i.Journals)
+	}
+	if len(i.Attachments) != 1 || i.Attachments[0].Filename != "log.txt" {
+		t.Errorf("unexpected attachments: %+v", i.Attachments)
+	}
+	if len(i.Watchers) != 2 || i.Watchers[1].Name != "Bob" {
+		t.Errorf("unexpected watchers: %+v", i.Watchers)
+	}
+}
@@ -0,0 +1,41 @@
+package redmine
+
+import "iter"
+
+// Iterate over all Redmine entities item by item, going through the pages lazily: the next
+// page is requested only when the items of previous one are consumed, so breaking the loop
+// stops the requests. The errors of malformed items (see [DecodeOptions]) are yielded along
+// the way, the error of request is yielded last and stops the iteration.
+//
+//	for issue, err := range redmine.Items[redmine.Issue](ac) {
+//		if err != nil {
+//			...
+//		}
+//	}
+func Items[E Entities](ac *ApiClient) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+		paginator := paginatorOf[E]()
+		for offset := 0; offset >= 0; {
+			r, err := GetOffset[E](ac, offset)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, err := range r.DecodeErrors {
+				if !yield(zero, err) {
+					return
+				}
+			}
+			for _, v := range r.Items {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if r.size() == 0 {
+				return
+			}
+			offset = r.next(paginator, offset)
+		}
+	}
+}
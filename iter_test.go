@@ -0,0 +1,54 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestItems(t *testing.T) {
+	var requests int
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != ProjectsApiEndpoint {
+			w.Write([]byte(`{"issues": [`))
+			return
+		}
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	i := 0
+	for p, err := range Items[Project](ac) {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		i++
+		if p.Id != i {
+			t.Errorf("expected %d, got %d", i, p.Id)
+		}
+	}
+	if i != TotalCount || requests != 5 {
+		t.Errorf("expected %d items in 5 requests, got: %d in %d", TotalCount, i, requests)
+	}
+
+	// early termination: only the first page is requested
+	requests = 0
+	for p := range Items[Project](ac) {
+		if p.Id == 10 {
+			break
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got: %d", requests)
+	}
+
+	for _, err := range Items[Issue](ac) {
+		if !errors.Is(err, JsonDecodeError) {
+			t.Errorf("expected JsonDecodeError, got: %s", err)
+		}
+	}
+}
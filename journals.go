@@ -0,0 +1,133 @@
+package redmine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// A Redmine journal entity: a note and/or set of changes of issue made by user at once.
+type Journal struct {
+	Id           int             `json:"id"`
+	User         NamedRef        `json:"user"`
+	Notes        string          `json:"notes"`
+	CreatedOn    DateTime        `json:"created_on"`
+	PrivateNotes FlexBool        `json:"private_notes"`
+	Details      []JournalDetail `json:"details"`
+}
+
+// A single change of journal: property is one of attr (issue attribute), cf (custom field),
+// attachment or relation, name is the name of attribute or id of custom field, attachment.
+type JournalDetail struct {
+	Property string `json:"property"`
+	Name     string `json:"name"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// A flattened event of issue change history, see [IssueHistory].
+type ChangeEvent struct {
+	When  time.Time
+	Who   string
+	Field string
+	From  string
+	To    string
+	Note  string
+}
+
+// Names of ids used for rendering of issue history, keyed by field, e.g.
+// {"status_id": {1: "New", 5: "Closed"}, "cf_3": {...}}. Custom fields are keyed by "cf_<id>".
+type FieldNames map[string]map[int]string
+
+// Resolve id value of field to name, the raw value is returned if the name is unknown.
+func (fn FieldNames) resolve(field, value string) string {
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return value
+	}
+	if name, ok := fn[field][id]; ok {
+		return name
+	}
+	return value
+}
+
+// Render the change history of issue fetched with include=journals as a chronological
+// list of events: every journal detail becomes an event with Field, From and To,
+// the journal notes become a separate event with empty Field.
+//
+// The ids of attributes (status_id, priority_id, assigned_to_id etc.) are mapped to names
+// via names if they are known, otherwise raw ids are shown, names may be nil.
+// Custom fields are rendered as "cf_<id>" fields, attachments as "attachment" and
+// relations as "relation_<type>". Deleted users are rendered as "Anonymous".
+func IssueHistory(issue Issue, names FieldNames) (events []ChangeEvent) {
+	journals := slices.Clone(issue.Journals)
+	slices.SortStableFunc(journals, func(a, b Journal) int { return a.CreatedOn.Compare(b.CreatedOn.Time) })
+
+	for _, j := range journals {
+		who := j.User.Name
+		if who == "" {
+			who = "Anonymous"
+		}
+		if j.Notes != "" {
+			events = append(events, ChangeEvent{When: j.CreatedOn.Time, Who: who, Note: j.Notes})
+		}
+		for _, d := range j.Details {
+			var field string
+			switch d.Property {
+			case "attr":
+				field = d.Name
+			case "cf":
+				field = "cf_" + d.Name
+			case "attachment":
+				field = "attachment"
+			case "relation":
+				field = "relation_" + d.Name
+			default:
+				field = fmt.Sprintf("%s_%s", d.Property, d.Name)
+			}
+			events = append(events, ChangeEvent{
+				When:  j.CreatedOn.Time,
+				Who:   who,
+				Field: field,
+				From:  names.resolve(field, d.OldValue),
+				To:    names.resolve(field, d.NewValue),
+			})
+		}
+	}
+	return
+}
+
+var EmptyNotesError = errors.New("notes must not be empty")
+
+// Payload of note added to issue, private notes are visible only to users with
+// the permission to view private notes.
+type AddNotePayload struct {
+	Notes        string `json:"notes"`
+	PrivateNotes bool   `json:"private_notes,omitempty"`
+}
+
+func (p AddNotePayload) Validate() error {
+	return requireNonEmpty(EmptyNotesError, "notes", p.Notes)
+}
+
+// Add a note to issue, e.g. an internal comment on customer-facing ticket if private is set.
+func (ac *ApiClient) AddNote(issueID int, notes string, private bool) error {
+	p := AddNotePayload{Notes: notes, PrivateNotes: private}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/issues/%d.json", issueID), &url.Values{}, 0)
+	if err != nil {
+		return errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	data, err := json.Marshal(PostEnvelope[AddNotePayload]{"issue", p})
+	if err != nil {
+		return errors.Join(JsonEncodeError, err)
+	}
+	return ac.Update(u, bytes.NewReader(data))
+}
@@ -0,0 +1,90 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIssueHistory(t *testing.T) {
+	data := `{
+	  "id": 1, "subject": "Subject 1",
+	  "journals": [
+	    {"id": 2, "user": {"id": 1, "name": "User1"}, "notes": "", "created_on": "2024-03-02T10:00:00Z",
+	     "details": [
+	       {"property": "attr", "name": "status_id", "old_value": "1", "new_value": "5"},
+	       {"property": "cf", "name": "3", "old_value": null, "new_value": "Sprint 2"}
+	     ]},
+	    {"id": 1, "user": {"id": 2, "name": "User2"}, "notes": "Looking into it", "created_on": "2024-03-01T10:00:00Z",
+	     "details": [{"property": "attr", "name": "assigned_to_id", "old_value": null, "new_value": "2"}]},
+	    {"id": 3, "user": {}, "notes": "Done", "created_on": "2024-03-03T10:00:00Z", "details": []}
+	  ]
+	}`
+	var issue Issue
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := FieldNames{"status_id": {1: "New", 5: "Closed"}}
+	events := IssueHistory(issue, names)
+
+	expected := []ChangeEvent{
+		{Who: "User2", Note: "Looking into it"},
+		{Who: "User2", Field: "assigned_to_id", To: "2"},
+		{Who: "User1", Field: "status_id", From: "New", To: "Closed"},
+		{Who: "User1", Field: "cf_3", To: "Sprint 2"},
+		{Who: "Anonymous", Note: "Done"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got: %d", len(expected), len(events))
+	}
+	for i, e := range events {
+		e.When = expected[i].When
+		if e != expected[i] {
+			t.Errorf("event %d: expected %+v, got: %+v", i, expected[i], e)
+		}
+	}
+	if !events[0].When.Before(events[4].When) {
+		t.Errorf("expected chronological order, got: %v", events)
+	}
+
+	// without names raw ids are shown
+	if e := IssueHistory(issue, nil)[2]; e.From != "1" || e.To != "5" {
+		t.Errorf("expected raw ids, got: %+v", e)
+	}
+}
+
+func TestAddNote(t *testing.T) {
+	var bodies []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/issues/7.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	if err := ac.AddNote(7, "internal", true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := ac.AddNote(7, "public", false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(bodies) != 2 || !strings.Contains(bodies[0], `"private_notes":true`) ||
+		strings.Contains(bodies[1], "private_notes") {
+		t.Errorf("unexpected payloads: %v", bodies)
+	}
+
+	if err := ac.AddNote(7, " ", false); !errors.Is(err, EmptyNotesError) {
+		t.Errorf("expected EmptyNotesError, got: %s", err)
+	}
+}
@@ -0,0 +1,117 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// A Redmine project membership: either user or group with roles in the project.
+type Membership struct {
+	Id      int        `json:"id"`
+	Project NamedRef   `json:"project"`
+	User    *NamedRef  `json:"user,omitempty"`
+	Group   *NamedRef  `json:"group,omitempty"`
+	Roles   []NamedRef `json:"roles"`
+}
+
+// Construct the URL of project memberships.
+func (ac *ApiClient) MembershipsUrl(projectID, offset int) (string, error) {
+	v := url.Values{}
+	ac.PageParams.setOffset(&v, offset)
+	ac.setLimit(&v)
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/projects/%d/memberships.json", projectID), &v, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get all memberships of project going through all the pages.
+func (ac *ApiClient) GetMemberships(projectID int) ([]Membership, error) {
+	var memberships []Membership
+	for offset := 0; offset >= 0; {
+		u, err := ac.MembershipsUrl(projectID, offset)
+		if err != nil {
+			return nil, err
+		}
+		r, err := getPage[Membership](ac, u, "memberships")
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, r.Items...)
+		if r.size() == 0 {
+			break
+		}
+		offset = r.next(OffsetPaginator, offset)
+	}
+	return memberships, nil
+}
+
+// Get the users who are members of project, e.g. for assignee picker scoped to project:
+// group memberships are skipped, users with multiple roles are deduplicated.
+func (ac *ApiClient) GetAssignableUsers(projectID int) ([]User, error) {
+	memberships, err := ac.GetMemberships(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]struct{})
+	users := []User{}
+	for _, m := range memberships {
+		if m.User == nil {
+			continue
+		}
+		if _, ok := seen[m.User.Id]; ok {
+			continue
+		}
+		seen[m.User.Id] = struct{}{}
+		users = append(users, User{Id: m.User.Id, Name: m.User.Name})
+	}
+	return users, nil
+}
+
+// Get a page of paginated collection wrapped under the given key, e.g. {"memberships": [...]},
+// for the collections which are not supported by [Scroll].
+func getPage[T any](ac *ApiClient, uri, key string) (*ApiResponse[T], error) {
+	res, err := ac.do(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if err = checkStatus(res); err != nil {
+		return nil, err
+	}
+	r, err := decodePage[T](res.Body, key)
+	ac.observeDecode(err)
+	return r, err
+}
+
+// Decode JSON page of collection wrapped under the given key.
+func decodePage[T any](body io.Reader, key string) (*ApiResponse[T], error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, errors.Join(IoReadError, err)
+	}
+
+	r := ApiResponse[T]{}
+	var envelope map[string]json.RawMessage
+	if err = json.Unmarshal(data, &envelope); err != nil {
+		return nil, errors.Join(JsonDecodeError, err)
+	}
+	if err = json.Unmarshal(data, &r.Pagination); err != nil {
+		return nil, errors.Join(JsonDecodeError, err)
+	}
+	items, ok := envelope[key]
+	if !ok {
+		return nil, errors.Join(JsonDecodeError, fmt.Errorf("key %q not found in response", key))
+	}
+	if err = json.Unmarshal(items, &r.Items); err != nil {
+		return nil, errors.Join(JsonDecodeError, err)
+	}
+	return &r, nil
+}
@@ -0,0 +1,60 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetAssignableUsers(t *testing.T) {
+	pages := []string{
+		`{"id": 1, "project": {"id": 1}, "user": {"id": 1, "name": "User1"}, "roles": [{"id": 3, "name": "Manager"}]},
+		 {"id": 2, "project": {"id": 1}, "group": {"id": 9, "name": "Group9"}, "roles": [{"id": 4, "name": "Developer"}]}`,
+		`{"id": 3, "project": {"id": 1}, "user": {"id": 2, "name": "User2"}, "roles": [{"id": 4, "name": "Developer"}]},
+		 {"id": 4, "project": {"id": 1}, "user": {"id": 1, "name": "User1"}, "roles": [{"id": 4, "name": "Developer"}]}`,
+	}
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/1/memberships.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		offset := 0
+		fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+		fmt.Fprintf(w, `{"memberships": [%s], "offset": %d, "limit": 2, "total_count": 4}`, pages[offset/2], offset)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	users, err := CreateApiConfig(testServer.URL).GetAssignableUsers(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []User{{Id: 1, Name: "User1"}, {Id: 2, Name: "User2"}}
+	if !slices.EqualFunc(users, expected, func(a, b User) bool { return a.Id == b.Id && a.Name == b.Name }) {
+		t.Errorf("expected %v, got: %v", expected, users)
+	}
+}
+
+func TestGetMembershipsIgnoredOffset(t *testing.T) {
+	var requests int
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// the gateway ignores the offset param and returns the first page again and again
+		w.Write([]byte(`{"memberships": [{"id": 1, "user": {"id": 1}}, {"id": 2, "user": {"id": 2}}],
+			"offset": 0, "limit": 2, "total_count": 4}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.PageParams = PageParams{Offset: "skip"}
+	memberships, err := ac.GetMemberships(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if requests != 2 || len(memberships) != 4 {
+		t.Errorf("expected 2 requests and 4 memberships, got: %d, %d", requests, len(memberships))
+	}
+}
@@ -0,0 +1,48 @@
+package redmine
+
+import (
+	"errors"
+	"time"
+)
+
+// Kinds of errors reported to [Metrics].
+const (
+	ErrorKindTransport  = "transport"   // network layer errors
+	ErrorKindStatus     = "status"      // non-2xx responses
+	ErrorKindJsonDecode = "json_decode" // malformed responses
+	ErrorKindIoRead     = "io_read"     // errors of reading response body
+)
+
+// Metrics hook invoked by client, e.g. to bridge it to Prometheus without importing
+// any metrics dependency into this package: request counts, latency histograms
+// and error counts by kind.
+type Metrics interface {
+	// Observe the completed request, status is 0 if the request failed on network layer.
+	ObserveRequest(method string, status int, dur time.Duration)
+	// Observe the error of the given kind: ErrorKindTransport, ErrorKindStatus etc.
+	ObserveError(kind string)
+}
+
+// No-op metrics, the default one.
+type NopMetrics struct{}
+
+func (NopMetrics) ObserveRequest(string, int, time.Duration) {}
+func (NopMetrics) ObserveError(string)                       {}
+
+func (ac *ApiClient) metrics() Metrics {
+	if ac.Metrics == nil {
+		return NopMetrics{}
+	}
+	return ac.Metrics
+}
+
+// Observe the decoding errors of response, network and status errors are observed on sending.
+func (ac *ApiClient) observeDecode(err error) {
+	switch {
+	case err == nil:
+	case errors.Is(err, JsonDecodeError):
+		ac.metrics().ObserveError(ErrorKindJsonDecode)
+	case errors.Is(err, IoReadError):
+		ac.metrics().ObserveError(ErrorKindIoRead)
+	}
+}
@@ -0,0 +1,53 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeMetrics struct {
+	requests map[int]int
+	errors   map[string]int
+}
+
+func (m *fakeMetrics) ObserveRequest(method string, status int, dur time.Duration) {
+	m.requests[status]++
+}
+
+func (m *fakeMetrics) ObserveError(kind string) { m.errors[kind]++ }
+
+func TestMetrics(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/1.json":
+			w.Write([]byte(`{"project": {"id": 1}}`))
+		case "/projects/2.json":
+			w.Write([]byte(`{"project": `))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	m := fakeMetrics{map[int]int{}, map[string]int{}}
+	ac := CreateApiConfig(testServer.URL)
+	ac.Metrics = &m
+	for id := 1; id <= 3; id++ {
+		ac.GetProject(id)
+	}
+	ac.Url = "sd://sdsdsd"
+	ac.GetProject(1)
+
+	if m.requests[200] != 2 || m.requests[404] != 1 || m.requests[0] != 1 {
+		t.Errorf("unexpected requests: %v", m.requests)
+	}
+	expected := map[string]int{ErrorKindJsonDecode: 1, ErrorKindStatus: 1, ErrorKindTransport: 1}
+	for k, v := range expected {
+		if m.errors[k] != v {
+			t.Errorf("expected %d %s errors, got: %v", v, k, m.errors)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Results of [FetchMulti]: all the projects, issues and time entries matching the filters
+// of client, Err joins the errors of all failed fetches.
+type MultiResult struct {
+	Projects    []Project
+	Issues      []Issue
+	TimeEntries []TimeEntry
+	Err         error
+}
+
+// Fetch projects, issues and time entries concurrently, e.g. for a dashboard. The fetches
+// share the client and so its budget of concurrent requests (see MaxConcurrent), the first
+// error cancels the others: they stop before fetching the next page.
+func FetchMulti(ac *ApiClient) MultiResult {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		res  MultiResult
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	fail := func(err error) {
+		if err == nil || errors.Is(err, context.Canceled) {
+			return
+		}
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+		cancel()
+	}
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		var err error
+		res.Projects, err = getAll[Project](ctx, ac)
+		fail(err)
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		res.Issues, err = getAll[Issue](ctx, ac)
+		fail(err)
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		res.TimeEntries, err = getAll[TimeEntry](ctx, ac)
+		fail(err)
+	}()
+	wg.Wait()
+
+	res.Err = errors.Join(errs...)
+	return res
+}
@@ -0,0 +1,53 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchMulti(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		switch r.URL.Path {
+		case ProjectsApiEndpoint:
+			w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+		case IssuesApiEndpoint:
+			w.Write([]byte(GenerateJSON(IssuesJSONResponseTpl, params)))
+		case TimeEntriesEndpoint:
+			w.Write([]byte(GenerateJSON(TimeEntriesJSONResponseTpl, params)))
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.MaxConcurrent = 2
+	res := FetchMulti(ac)
+	if res.Err != nil {
+		t.Fatalf("unexpected error: %s", res.Err)
+	}
+	if len(res.Projects) != TotalCount || len(res.Issues) != TotalCount || len(res.TimeEntries) != TotalCount {
+		t.Errorf("unexpected number of items: %d, %d, %d",
+			len(res.Projects), len(res.Issues), len(res.TimeEntries))
+	}
+}
+
+func TestFetchMultiError(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == IssuesApiEndpoint {
+			w.Write([]byte(`{"issues": [`))
+			return
+		}
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	res := FetchMulti(CreateApiConfig(testServer.URL))
+	if !errors.Is(res.Err, JsonDecodeError) {
+		t.Errorf("expected JsonDecodeError, got: %s", res.Err)
+	}
+}
@@ -0,0 +1,68 @@
+package redmine
+
+import (
+	"cmp"
+	"net/url"
+	"strconv"
+)
+
+// Pagination strategy of entity: compute the offset of the next page from the pagination
+// of the current one, negative value means there are no more pages.
+//
+// Entities may supply their own strategy by implementing method Paginator() Paginator,
+// otherwise [OffsetPaginator] is used by [Scroll].
+type Paginator interface {
+	Next(current Pagination) int
+}
+
+// The function adapter of [Paginator] interface.
+type PaginatorFunc func(current Pagination) int
+
+func (f PaginatorFunc) Next(current Pagination) int { return f(current) }
+
+// The standard Redmine offset&limit pagination: go on until total count is reached.
+var OffsetPaginator Paginator = PaginatorFunc(func(p Pagination) int {
+	next := p.Offset + p.Limit
+	if p.Limit <= 0 || next >= p.Total {
+		return -1
+	}
+	return next
+})
+
+// Get the pagination strategy of entity.
+func paginatorOf[E any]() Paginator {
+	if p, ok := any(*new(E)).(interface{ Paginator() Paginator }); ok {
+		return p.Paginator()
+	}
+	return OffsetPaginator
+}
+
+// Names of pagination query params and the way of pagination, e.g. for a gateway which
+// rewrites the standard params of Redmine.
+type PageParams struct {
+	Page   string // name of page number param, "page" by default
+	Offset string // name of offset param, "offset" by default
+
+	// Request the pages by number (of the given size, sent as limit) instead of offset,
+	// zero means the offset pagination.
+	PageSize int
+}
+
+// Set page number param, the first page is the default one and is omitted.
+func (p PageParams) setPage(v *url.Values, page int) {
+	if page > 1 {
+		v.Set(cmp.Or(p.Page, "page"), strconv.Itoa(page))
+	}
+}
+
+// Set offset param or the number of page containing the offset if PageSize is set.
+func (p PageParams) setOffset(v *url.Values, offset int) {
+	if p.PageSize > 0 {
+		v.Set("limit", strconv.Itoa(p.PageSize))
+		p.setPage(v, offset/p.PageSize+1)
+		return
+	}
+	if offset > 0 {
+		v.Set(cmp.Or(p.Offset, "offset"), strconv.Itoa(offset))
+	}
+}
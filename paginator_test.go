@@ -0,0 +1,83 @@
+package redmine
+
+import "testing"
+
+func TestPaginators(t *testing.T) {
+	cases := []struct {
+		p        Pagination
+		expected int
+	}{
+		{Pagination{Offset: 0, Limit: 25, Total: 53}, 25},
+		{Pagination{Offset: 25, Limit: 25, Total: 53}, 50},
+		{Pagination{Offset: 50, Limit: 25, Total: 53}, -1},
+		{Pagination{Offset: 0, Limit: 0, Total: 0}, -1},
+	}
+	for _, c := range cases {
+		if next := OffsetPaginator.Next(c.p); next != c.expected {
+			t.Errorf("%+v: expected %d, got: %d", c.p, c.expected, next)
+		}
+	}
+
+	if paginatorOf[Issue]().Next(cases[0].p) != cases[0].expected {
+		t.Error("expected OffsetPaginator for issues")
+	}
+	if paginatorOf[singlePage]().Next(cases[0].p) != -1 {
+		t.Error("expected the own paginator of entity")
+	}
+}
+
+// Non-paginated entity, e.g. enumeration: the first page is the only one.
+type singlePage struct{}
+
+func (singlePage) Paginator() Paginator {
+	return PaginatorFunc(func(Pagination) int { return -1 })
+}
+
+func TestPageParams(t *testing.T) {
+	ac := CreateApiConfig("https://example.com")
+	cases := []struct {
+		params   PageParams
+		page     int
+		offset   int
+		expected string
+	}{
+		{PageParams{}, 3, 0, "https://example.com/projects.json?page=3"},
+		{PageParams{}, 0, 50, "https://example.com/projects.json?offset=50"},
+		{PageParams{Page: "p", Offset: "skip"}, 3, 0, "https://example.com/projects.json?p=3"},
+		{PageParams{Page: "p", Offset: "skip"}, 0, 50, "https://example.com/projects.json?skip=50"},
+		{PageParams{Page: "p", PageSize: 25}, 0, 50, "https://example.com/projects.json?limit=25&p=3"},
+		{PageParams{PageSize: 25}, 0, 0, "https://example.com/projects.json?limit=25"},
+	}
+	for _, c := range cases {
+		ac.PageParams = c.params
+		var u string
+		if c.page > 0 {
+			u, _ = ApiEndpointURL[Project](ac, c.page)
+		} else {
+			u, _ = ApiEndpointOffsetURL[Project](ac, c.offset)
+		}
+		if u != c.expected {
+			t.Errorf("%+v: expected %s, got: %s", c.params, c.expected, u)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	ac := CreateApiConfig("https://example.com")
+	cases := []struct {
+		limit    int
+		page     int
+		expected string
+	}{
+		{0, 2, "https://example.com/projects.json?page=2"},
+		{50, 0, "https://example.com/projects.json?limit=50"},
+		{50, 3, "https://example.com/projects.json?limit=50&page=3"},
+		{500, 2, "https://example.com/projects.json?limit=100&page=2"},
+	}
+	for _, c := range cases {
+		ac.Limit = c.limit
+		if u, _ := ApiEndpointURL[Project](ac, c.page); u != c.expected {
+			t.Errorf("limit %d: expected %s, got: %s", c.limit, c.expected, u)
+		}
+	}
+}
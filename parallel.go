@@ -0,0 +1,149 @@
+package redmine
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Options of [ScrollParallel].
+type ParallelOptions struct {
+	// Number of pages fetched concurrently, 4 if not set.
+	Workers int
+	// Emit the pages in order of offsets: the pages completed out of order are held
+	// in a reorder buffer until all the previous ones are emitted.
+	Ordered bool
+}
+
+// A page fetched by worker of parallel scroll.
+type parallelPage[E any] struct {
+	index int
+	items []E
+}
+
+// Scroll over Redmine API paginated responses like [Scroll], but fetch the pages
+// concurrently: the first page is requested to learn the total count and the page size,
+// then the rest pages are requested by workers. The pages are emitted as they complete,
+// unless Ordered option is set.
+//
+// The failed requests are retried according to the retry policy of client, then the page
+// is skipped with error. After a fatal error (see [IsFatal]) no more pages are requested.
+func ScrollParallel[E Entities](ac *ApiClient, opts ParallelOptions) (<-chan E, <-chan error) {
+	dataChan := make(chan E)
+	errChan := make(chan error)
+
+	go func() {
+		defer close(dataChan)
+		defer close(errChan)
+
+		first, err := getPageRetrying[E](ac, 0, errChan)
+		if err != nil {
+			return
+		}
+		for _, v := range first.Items {
+			dataChan <- v
+		}
+		if first.Limit <= 0 || first.Total <= first.Limit {
+			if first.Limit == 0 && first.Total == 0 && first.size() > 0 {
+				// no pagination metadata, the number of pages is unknown
+				scrollFrom(context.Background(), ac, first.size(), dataChan, errChan, nil, nil)
+			}
+			return
+		}
+
+		workers := opts.Workers
+		if workers <= 0 {
+			workers = 4
+		}
+
+		offsets := make(chan int)
+		pages := make(chan parallelPage[E])
+		var (
+			wg    sync.WaitGroup
+			fatal sync.Once
+			stop  = make(chan struct{})
+		)
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for offset := range offsets {
+					r, err := getPageRetrying[E](ac, offset, errChan)
+					var items []E
+					switch {
+					case IsFatal(err):
+						fatal.Do(func() { close(stop) })
+					case err == nil:
+						items = r.Items
+					}
+					// the failed pages are sent empty to not block the reordering
+					pages <- parallelPage[E]{offset / first.Limit, items}
+				}
+			}()
+		}
+		go func() {
+			defer close(offsets)
+			for offset := first.Limit; offset < first.Total; offset += first.Limit {
+				select {
+				case offsets <- offset:
+				case <-stop:
+					return
+				}
+			}
+		}()
+		go func() {
+			wg.Wait()
+			close(pages)
+		}()
+
+		if !opts.Ordered {
+			for p := range pages {
+				for _, v := range p.items {
+					dataChan <- v
+				}
+			}
+			return
+		}
+
+		// reorder buffer: index of the next page to emit and the pages completed ahead of it
+		next, buf := 1, make(map[int][]E)
+		for p := range pages {
+			buf[p.index] = p.items
+			for {
+				items, ok := buf[next]
+				if !ok {
+					break
+				}
+				delete(buf, next)
+				next++
+				for _, v := range items {
+					dataChan <- v
+				}
+			}
+		}
+	}()
+
+	return dataChan, errChan
+}
+
+// Get the page at offset retrying the failures according to the retry policy of client,
+// every error is sent to errs, the last one is returned.
+func getPageRetrying[E Entities](ac *ApiClient, offset int, errs chan<- error) (*ApiResponse[E], error) {
+	for attempt := 0; ; attempt++ {
+		r, err := GetOffset[E](ac, offset)
+		if err == nil {
+			for _, err := range r.DecodeErrors {
+				errs <- err
+			}
+			return r, nil
+		}
+		errs <- err
+		rp := ac.retryPolicy()
+		if !retryable(err) || !rp.Allow(attempt) {
+			return nil, err
+		}
+		delay := max(rp.Delay(attempt), RetryAfter(err))
+		ac.logRetry(attempt, delay, err)
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,92 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScrollParallel(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		// the later pages complete first
+		time.Sleep(time.Duration(TotalCount-params.Offset) * time.Millisecond / 4)
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	collect := func(opts ParallelOptions) (ids []int) {
+		dataChan, errChan := ScrollParallel[Project](CreateApiConfig(testServer.URL), opts)
+		go func() {
+			for err := range errChan {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+		for p := range dataChan {
+			ids = append(ids, p.Id)
+		}
+		return
+	}
+
+	ids := collect(ParallelOptions{Workers: 4, Ordered: true})
+	if len(ids) != TotalCount || !slices.IsSorted(ids) {
+		t.Errorf("expected %d items in ascending order, got: %v", TotalCount, ids)
+	}
+
+	ids = collect(ParallelOptions{Workers: 4})
+	if len(ids) != TotalCount {
+		t.Errorf("expected %d items, got: %d", TotalCount, len(ids))
+	}
+	if slices.IsSorted(ids) {
+		t.Errorf("expected out of order items without Ordered option")
+	}
+	slices.Sort(ids)
+	if ids = slices.Compact(ids); len(ids) != TotalCount {
+		t.Errorf("expected %d unique items, got: %d", TotalCount, len(ids))
+	}
+}
+
+func TestScrollParallelRateLimited(t *testing.T) {
+	var limited atomic.Bool
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		if params.Offset == PaginationLimit && limited.CompareAndSwap(false, true) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	start := time.Now()
+	dataChan, errChan := ScrollParallel[Project](CreateApiConfig(testServer.URL), ParallelOptions{Workers: 2})
+	var errs []error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for err := range errChan {
+			errs = append(errs, err)
+		}
+	}()
+	var items int
+	for range dataChan {
+		items++
+	}
+	<-done
+	if len(errs) != 1 || !errors.Is(errs[0], RateLimitError) {
+		t.Errorf("expected RateLimitError, got: %v", errs)
+	}
+	if items != TotalCount {
+		t.Errorf("expected %d items, got: %d", TotalCount, items)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected retry after 1s, got: %s", elapsed)
+	}
+}
@@ -0,0 +1,76 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// Construct the URL of single project with optional associated data, e.g. include=trackers.
+func (ac *ApiClient) ProjectUrl(id int, include ...string) (string, error) {
+	v := url.Values{}
+	setInclude(&v, ac.includes("projects", include...))
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/projects/%d.json", id), &v, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get a single project by id, include is a list of associated data to fetch along
+// with the project: trackers, issue_categories, enabled_modules, time_entry_activities etc.
+func (ac *ApiClient) GetProject(id int, include ...string) (*Project, error) {
+	u, err := ac.ProjectUrl(id, include...)
+	if err != nil {
+		return nil, err
+	}
+	return GetOne[Project](ac, u, "project")
+}
+
+// Check whether the tracker is enabled for the project. Creating an issue with a tracker
+// which is not enabled for its project is rejected by Redmine with generic 422 error,
+// so this is a useful pre-flight check.
+func (ac *ApiClient) IsTrackerEnabled(projectID, trackerID int) (bool, error) {
+	p, err := ac.GetProject(projectID, "trackers")
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(p.Trackers, func(t NamedRef) bool { return t.Id == trackerID }), nil
+}
+
+// Get the subprojects of parent project, if recursive is set, all the descendants
+// are returned (children go right after their parent), otherwise only direct children.
+func (ac *ApiClient) Subprojects(parentID int, recursive bool) ([]Project, error) {
+	projects, err := GetAll[Project](ac)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[int][]Project)
+	for _, p := range projects {
+		if p.Parent != nil {
+			children[p.Parent.Id] = append(children[p.Parent.Id], p)
+		}
+	}
+
+	var (
+		res  []Project
+		walk func(id int)
+	)
+	seen := make(map[int]bool) // guard against cycles of malformed data
+	walk = func(id int) {
+		for _, p := range children[id] {
+			if seen[p.Id] {
+				continue
+			}
+			seen[p.Id] = true
+			res = append(res, p)
+			if recursive {
+				walk(p.Id)
+			}
+		}
+	}
+	walk(parentID)
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestIsTrackerEnabled(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/1.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("include") != "trackers" {
+			t.Errorf("expected include=trackers, got: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"project": {"id": 1, "name": "Project1", "trackers": [
+			{"id": 1, "name": "Bug"}, {"id": 3, "name": "Support"}]}}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+
+	for trackerID, expected := range map[int]bool{1: true, 2: false, 3: true} {
+		ok, err := ac.IsTrackerEnabled(1, trackerID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ok != expected {
+			t.Errorf("tracker %d: expected %t, got %t", trackerID, expected, ok)
+		}
+	}
+
+	if _, err := ac.IsTrackerEnabled(2, 1); !errors.Is(err, NotFoundError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+}
+
+func TestSubprojects(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"projects": [
+			{"id": 1, "name": "Root"},
+			{"id": 2, "name": "Child2", "parent": {"id": 1, "name": "Root"}},
+			{"id": 3, "name": "Child3", "parent": {"id": 1, "name": "Root"}},
+			{"id": 4, "name": "Grandchild4", "parent": {"id": 2, "name": "Child2"}},
+			{"id": 5, "name": "Other"}
+		], "offset": 0, "limit": 25, "total_count": 5}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	ids := func(projects []Project) (res []int) {
+		for _, p := range projects {
+			res = append(res, p.Id)
+		}
+		return
+	}
+
+	projects, err := ac.Subprojects(1, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := ids(projects); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("expected [2 3], got: %v", got)
+	}
+
+	projects, _ = ac.Subprojects(1, true)
+	if got := ids(projects); !slices.Equal(got, []int{2, 4, 3}) {
+		t.Errorf("expected [2 4 3], got: %v", got)
+	}
+}
+
+func TestProjectParent(t *testing.T) {
+	data := `{"projects": [
+		{"id": 1, "name": "Root", "identifier": "root"},
+		{"id": 2, "name": "Child", "identifier": "child", "parent": {"id": 1, "name": "Root"}}
+	], "offset": 0, "limit": 25, "total_count": 2}`
+	r, err := DecodeResp[Project](io.NopCloser(strings.NewReader(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p := r.Items[0].Parent; p != nil {
+		t.Errorf("expected top-level project, got parent: %+v", p)
+	}
+	if p := r.Items[1].Parent; p == nil || *p != (NamedRef{Id: 1, Name: "Root"}) {
+		t.Errorf("expected parent Root, got: %+v", p)
+	}
+
+	b, _ := json.Marshal(r.Items[0])
+	if strings.Contains(string(b), "parent") {
+		t.Errorf("expected omitted parent, got: %s", b)
+	}
+}
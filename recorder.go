@@ -0,0 +1,113 @@
+package redmine
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// The placeholder of Redmine url used by replay client, the recorded responses are matched
+// regardless of host, so any url will do.
+const ReplayUrl = "http://redmine.replay"
+
+var ReplayMissError = errors.New("no recorded response")
+
+// A recorded http response.
+type recording struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// Record/replay http transport for testing without a live Redmine server: in record mode
+// it sends requests via underlying transport and saves the responses to Dir as JSON files,
+// in replay mode it serves the saved responses only. The responses are matched by method,
+// path and query string of request.
+type Recorder struct {
+	Dir       string
+	Replay    bool
+	Transport http.RoundTripper // underlying transport of record mode, nil means default
+}
+
+// Name of recording file of request.
+func (r *Recorder) file(req *http.Request) string {
+	h := sha1.Sum([]byte(req.Method + " " + req.URL.RequestURI()))
+	return filepath.Join(r.Dir, hex.EncodeToString(h[:])+".json")
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.Replay {
+		return r.replay(req)
+	}
+
+	t := r.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	res, err := t.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(recording{
+		req.Method, req.URL.String(), res.StatusCode, res.Header, string(body)}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(r.file(req), data, 0o644); err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	data, err := os.ReadFile(r.file(req))
+	if err != nil {
+		return nil, errors.Join(ReplayMissError, fmt.Errorf("%s %s", req.Method, req.URL), err)
+	}
+	var rec recording
+	if err = json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode: rec.Status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     rec.Header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(rec.Body))),
+		Request:    req,
+	}, nil
+}
+
+// Create an API client which records all the responses of Redmine server to dir.
+func NewRecordingClient(url, token, dir string) *ApiClient {
+	ac := CreateApiClient(url, token, false, TimeEntriesFilter{})
+	ac.HTTPClient = &http.Client{Transport: &Recorder{Dir: dir}}
+	return ac
+}
+
+// Create an API client which serves only the responses recorded to dir by [NewRecordingClient],
+// requests without recorded response fail with [ReplayMissError] wrapped into [HttpError].
+func NewReplayClient(dir string) *ApiClient {
+	ac := CreateApiClient(ReplayUrl, "", false, TimeEntriesFilter{})
+	ac.HTTPClient = &http.Client{Transport: &Recorder{Dir: dir, Replay: true}}
+	return ac
+}
@@ -0,0 +1,46 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		params := GetResponseParamsFromUrl(r.URL.RawQuery)
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, params)))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	dir := t.TempDir()
+
+	scroll := func(ac *ApiClient) (n int, err error) {
+		dataChan, errChan := Scroll[Project](ac)
+		for {
+			select {
+			case _, ok := <-dataChan:
+				if !ok {
+					return
+				}
+				n++
+			case err = <-errChan:
+				return
+			}
+		}
+	}
+
+	if n, err := scroll(NewRecordingClient(testServer.URL, "ababab", dir)); n != TotalCount || err != nil {
+		t.Fatalf("record: expected %d items, got: %d, %v", TotalCount, n, err)
+	}
+	testServer.Close()
+
+	ac := NewReplayClient(dir)
+	if n, err := scroll(ac); n != TotalCount || err != nil {
+		t.Errorf("replay: expected %d items, got: %d, %v", TotalCount, n, err)
+	}
+
+	if _, err := ac.GetProject(1); !errors.Is(err, ReplayMissError) || !errors.Is(err, HttpError) {
+		t.Errorf("expected ReplayMissError, got: %s", err)
+	}
+}
@@ -0,0 +1,36 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// A Redmine issue relation, e.g. relates, blocks, precedes.
+type Relation struct {
+	Id           int    `json:"id"`
+	IssueID      int    `json:"issue_id"`
+	IssueToID    int    `json:"issue_to_id"`
+	RelationType string `json:"relation_type"`
+	Delay        *int   `json:"delay"` // only for precedes and follows relations
+}
+
+// Construct the URL of single issue relation.
+func (ac *ApiClient) RelationUrl(id int) (string, error) {
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/relations/%d.json", id), &url.Values{}, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Delete the issue relation. The already removed relation is reported as [NotFoundError],
+// so the caller may treat it as success if it wants.
+func (ac *ApiClient) DeleteRelation(relationID int) error {
+	u, err := ac.RelationUrl(relationID)
+	if err != nil {
+		return err
+	}
+	_, err = ac.Delete(u)
+	return err
+}
@@ -0,0 +1,34 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeleteRelation(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got: %s", r.Method)
+		}
+		if r.Header.Get("X-Redmine-API-Key") == "" {
+			t.Error("expected api key header")
+		}
+		if r.URL.Path != "/relations/1.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	if err := ac.DeleteRelation(1); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := ac.DeleteRelation(2); !errors.Is(err, NotFoundError) || !errors.Is(err, HttpError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+}
@@ -0,0 +1,146 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Retry policy of failed requests: the number of retries and the exponential backoff
+// with full jitter between them, the zero value means [DefaultRetryPolicy]. Only
+// the transient failures are retried: network errors, malformed (e.g. truncated or HTML
+// of proxy) bodies, 429 and 5xx responses. The policy applies to scrolls and to the
+// idempotent write requests: PUT (e.g. [ApiClient.Update]) and DELETE. POST (e.g.
+// [ApiClient.Create]) is never retried: the request failed with 5xx or timeout may still
+// have created the entity, so its retry could create a duplicate.
+//
+// The jitter is needed to avoid the thundering herd problem: when multiple workers
+// hit the rate limit or server errors at once and retry with identical backoff,
+// they re-collide again and again.
+type RetryPolicy struct {
+	MaxRetries int           // max retries of the same request, 0 means unlimited, negative no retries
+	BaseDelay  time.Duration // backoff of the first retry
+	MaxDelay   time.Duration // the cap of backoff, 0 means no cap
+}
+
+// Retry policy of clients created by [CreateApiClient]: about a minute of retries at most.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 5, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+// Compute the delay before the given (starting from zero) retry attempt:
+// a random duration in [0, min(MaxDelay, BaseDelay * 2^attempt)].
+func (rp RetryPolicy) Delay(attempt int) time.Duration {
+	if rp.BaseDelay <= 0 {
+		return 0
+	}
+	backoff := rp.BaseDelay
+	for i := 0; i < attempt && (rp.MaxDelay <= 0 || backoff < rp.MaxDelay); i++ {
+		if backoff > math.MaxInt64/2 {
+			// prevent overflow, rand.N needs room for +1
+			backoff = math.MaxInt64 - 1
+			break
+		}
+		backoff *= 2
+	}
+	if rp.MaxDelay > 0 && backoff > rp.MaxDelay {
+		backoff = rp.MaxDelay
+	}
+	return rand.N(backoff + 1)
+}
+
+// Check whether one more retry is allowed after the given number of attempts.
+func (rp RetryPolicy) Allow(attempt int) bool {
+	return rp.MaxRetries == 0 || attempt < rp.MaxRetries
+}
+
+// Get the retry policy of client, the zero one is replaced by [DefaultRetryPolicy],
+// so the client built as struct literal doesn't hammer the failing server.
+func (ac *ApiClient) retryPolicy() RetryPolicy {
+	if ac.Retry == (RetryPolicy{}) {
+		return DefaultRetryPolicy
+	}
+	return ac.Retry
+}
+
+// Log the upcoming retry (counting from one) with its delay and the error which caused it.
+func (ac *ApiClient) logRetry(attempt int, delay time.Duration, err error) {
+	limit := "unlimited"
+	if rp := ac.retryPolicy(); rp.MaxRetries > 0 {
+		limit = strconv.Itoa(rp.MaxRetries)
+	}
+	ac.logf("retry %d/%s in %s: %s", attempt+1, limit, delay, err)
+}
+
+// Unexpected status of response carried by [HttpError], see [retryable].
+type statusError struct {
+	code   int
+	status string
+}
+
+func (e statusError) Error() string {
+	return "unexpected status: " + e.status
+}
+
+func newStatusError(res *http.Response) error {
+	return statusError{res.StatusCode, res.Status}
+}
+
+// Check whether the failed request is worth retrying: network errors, malformed
+// (e.g. truncated) bodies, 429 and 5xx responses are transient, while the other 4xx ones
+// (bad filter, missing resource, rejected payload) fail the same way again.
+func retryable(err error) bool {
+	switch {
+	case IsFatal(err), errors.Is(err, NotFoundError):
+		return false
+	case errors.Is(err, RateLimitedError):
+		return true
+	}
+	var s statusError
+	if errors.As(err, &s) {
+		return s.code >= 500
+	}
+	return true
+}
+
+// The wait duration requested by server in Retry-After header of 429 response.
+type retryAfter time.Duration
+
+func (d retryAfter) Error() string {
+	return fmt.Sprintf("retry after %s", time.Duration(d))
+}
+
+// Build [RateLimitedError] of 429 response carrying the duration of Retry-After.
+func rateLimitedError(res *http.Response) error {
+	return errors.Join(HttpError, RateLimitedError, newStatusError(res),
+		retryAfter(parseRetryAfter(res.Header.Get("Retry-After"), time.Now())))
+}
+
+// Parse Retry-After header: either delay in seconds or HTTP date, the missing, malformed
+// or past value is zero duration.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// Get the wait duration requested by server of [RateLimitedError], zero for other errors
+// and for 429 responses without Retry-After.
+func RetryAfter(err error) time.Duration {
+	var d retryAfter
+	if errors.As(err, &d) {
+		return time.Duration(d)
+	}
+	return 0
+}
@@ -0,0 +1,325 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	rp := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: time.Second}
+	for attempt := 0; attempt < 64; attempt++ {
+		upper := rp.BaseDelay << attempt
+		if attempt >= 7 || upper > rp.MaxDelay {
+			upper = rp.MaxDelay
+		}
+		for i := 0; i < 100; i++ {
+			if d := rp.Delay(attempt); d < 0 || d > upper {
+				t.Fatalf("attempt %d: delay %s is out of [0, %s]", attempt, d, upper)
+			}
+		}
+	}
+
+	if d := (RetryPolicy{}).Delay(3); d != 0 {
+		t.Errorf("expected zero delay, got: %s", d)
+	}
+}
+
+func TestScrollRetries(t *testing.T) {
+	apiConfig := CreateApiConfig("sd://sdsdsd")
+	apiConfig.Retry = RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+	logger := fakeLogger{}
+	apiConfig.LogEnabled = true
+	apiConfig.Logger = &logger
+	dataChan, errChan := Scroll[Project](apiConfig)
+
+	var errs int
+	for err := range errChan {
+		if !errors.Is(err, HttpError) {
+			t.Errorf("expected HttpError, got: %s", err)
+		}
+		errs++
+	}
+	if errs != 3 {
+		t.Errorf("expected 3 errors (1 request + 2 retries), got: %d", errs)
+	}
+	if _, ok := <-dataChan; ok {
+		t.Error("expected closed data channel")
+	}
+	var retries []string
+	for _, l := range logger.lines {
+		if strings.HasPrefix(l, "retry ") {
+			retries = append(retries, l)
+		}
+	}
+	if len(retries) != 2 || !strings.HasPrefix(retries[0], "retry 1/2 in ") ||
+		!strings.HasPrefix(retries[1], "retry 2/2 in ") {
+		t.Errorf("expected 2 retry log lines, got: %q", retries)
+	}
+}
+
+func TestScrollFatalErrors(t *testing.T) {
+	var requests int
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.Header.Get("X-Redmine-API-Key") == "wrong":
+			w.WriteHeader(http.StatusUnauthorized)
+		case requests == 1:
+			// transient failure of proxy
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("Bad Gateway"))
+		default:
+			w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	scroll := func(ac *ApiClient) (items int, errs []error) {
+		dataChan, errChan := Scroll[Project](ac)
+		for dataChan != nil || errChan != nil {
+			select {
+			case _, ok := <-dataChan:
+				if !ok {
+					dataChan = nil
+					continue
+				}
+				items++
+			case err, ok := <-errChan:
+				if !ok {
+					errChan = nil
+					continue
+				}
+				errs = append(errs, err)
+			}
+		}
+		return
+	}
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.Retry.BaseDelay = time.Millisecond
+	items, errs := scroll(ac)
+	if items != TotalCount || len(errs) != 1 || IsFatal(errs[0]) {
+		t.Errorf("expected all items after a transient error, got: %d, %v", items, errs)
+	}
+
+	requests = 0
+	ac.Token = "wrong"
+	items, errs = scroll(ac)
+	if items != 0 || len(errs) != 1 || !errors.Is(errs[0], AuthError) || !IsFatal(errs[0]) {
+		t.Errorf("expected single AuthError, got: %d, %v", items, errs)
+	}
+	if requests != 1 {
+		t.Errorf("expected no retries after fatal error, got %d requests", requests)
+	}
+}
+
+func TestScrollRetriesOnlyTransientErrors(t *testing.T) {
+	var requests int32
+	status := http.StatusUnprocessableEntity
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(status)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	if ac.Retry != DefaultRetryPolicy || ac.Retry.MaxRetries == 0 {
+		t.Errorf("expected finite default retry policy, got: %+v", ac.Retry)
+	}
+	ac.Retry.BaseDelay = time.Millisecond
+
+	for _, c := range []struct {
+		status   int
+		requests int32
+	}{
+		{http.StatusUnprocessableEntity, 1},
+		{http.StatusBadRequest, 1},
+		{http.StatusNotFound, 1},
+		{http.StatusServiceUnavailable, int32(ac.Retry.MaxRetries) + 1},
+	} {
+		status = c.status
+		atomic.StoreInt32(&requests, 0)
+		dataChan, errChan := Scroll[Project](ac)
+		go func() {
+			for range dataChan {
+			}
+		}()
+		var errs int32
+		var last error
+		for err := range errChan {
+			if !errors.Is(err, HttpError) {
+				t.Errorf("%d: expected HttpError, got: %s", c.status, err)
+			}
+			errs++
+			last = err
+		}
+		if n := atomic.LoadInt32(&requests); n != c.requests || errs != c.requests {
+			t.Errorf("%d: expected %d requests and errors, got: %d, %d", c.status, c.requests, n, errs)
+		}
+		// the stream is dead after the last error, the consumer can tell it
+		if !IsFatal(last) || !errors.Is(last, ScrollStoppedError) {
+			t.Errorf("%d: expected the last error to be fatal, got: %s", c.status, last)
+		}
+		if _, ok := <-dataChan; ok {
+			t.Errorf("%d: expected closed data channel", c.status)
+		}
+	}
+}
+
+func TestZeroRetryPolicy(t *testing.T) {
+	var requests int32
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := &ApiConfig{Url: testServer.URL}
+	if rp := ac.retryPolicy(); rp != DefaultRetryPolicy {
+		t.Errorf("expected default retry policy of zero one, got: %+v", rp)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	dataChan, errChan := ScrollContext[Issue](ctx, ac)
+	go func() {
+		for range dataChan {
+		}
+	}()
+	for range errChan {
+	}
+	// the default backoff is a second, so there is no time for more than a few retries
+	if n := atomic.LoadInt32(&requests); n > 10 {
+		t.Errorf("expected backoff between retries, got %d requests", n)
+	}
+
+	ac = &ApiConfig{Url: testServer.URL, Retry: RetryPolicy{MaxRetries: -1}}
+	atomic.StoreInt32(&requests, 0)
+	items, errs := Scroll[Issue](ac)
+	go func() {
+		for range items {
+		}
+	}()
+	for range errs {
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected no retries, got %d requests", n)
+	}
+}
+
+func TestScrollMalformedPageRetries(t *testing.T) {
+	var requests int32
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"projects": [{"id": 1`)) // truncated body
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.Retry = RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+	dataChan, errChan := Scroll[Project](ac)
+	go func() {
+		for range dataChan {
+		}
+	}()
+	var errs int
+	for err := range errChan {
+		if !errors.Is(err, JsonDecodeError) {
+			t.Errorf("expected JsonDecodeError, got: %s", err)
+		}
+		errs++
+	}
+	if n := atomic.LoadInt32(&requests); errs != 3 || n != 3 {
+		t.Errorf("expected 3 requests and errors (1 request + 2 retries), got: %d, %d", n, errs)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{" 3 ", 3 * time.Second},
+		{"-5", 0},
+		{"Fri, 01 Mar 2024 12:00:30 GMT", 30 * time.Second},
+		{"Fri, 01 Mar 2024 11:00:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, c := range cases {
+		if got := parseRetryAfter(c.value, now); got != c.want {
+			t.Errorf("%q: expected %s, got: %s", c.value, c.want, got)
+		}
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	var requests int32
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("slow down"))
+			return
+		}
+		if r.Method == http.MethodPost {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	// the first page is retried after the delay requested by server
+	start := time.Now()
+	dataChan, errChan := Scroll[Project](ac)
+	var items int
+	var errs []error
+	for dataChan != nil || errChan != nil {
+		select {
+		case _, ok := <-dataChan:
+			if !ok {
+				dataChan = nil
+				continue
+			}
+			items++
+		case err, ok := <-errChan:
+			if !ok {
+				errChan = nil
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], RateLimitedError) || RetryAfter(errs[0]) != time.Second {
+		t.Errorf("expected RateLimitedError with retry after 1s, got: %v", errs)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected retry after 1s, got: %s", elapsed)
+	}
+	if items != TotalCount {
+		t.Errorf("expected %d items, got: %d", TotalCount, items)
+	}
+
+	err := ac.Create(testServer.URL+"/issues.json", strings.NewReader(`{}`))
+	if !errors.Is(err, RateLimitedError) || !errors.Is(err, HttpError) || RetryAfter(err) != 7*time.Second {
+		t.Errorf("expected RateLimitedError with retry after 7s, got: %v", err)
+	}
+	if RetryAfter(HttpError) != 0 {
+		t.Error("expected zero retry after of other errors")
+	}
+}
@@ -0,0 +1,120 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var ServerVersionError = errors.New("cannot parse Redmine version")
+
+// Version of Redmine server.
+type RedmineVersion struct {
+	Major, Minor, Patch int
+}
+
+// Parse version like "5.1.2" or "4.2", the suffixes like ".stable" are ignored.
+func ParseRedmineVersion(s string) (RedmineVersion, error) {
+	var v RedmineVersion
+	parts := strings.SplitN(strings.TrimSpace(s), ".", 4)
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if i >= len(parts) {
+			break
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			if i > 0 {
+				break // e.g. "4.2.stable"
+			}
+			return v, errors.Join(ServerVersionError, fmt.Errorf("version %q: %w", s, err))
+		}
+		*p = n
+	}
+	return v, nil
+}
+
+// Check whether the version is the same or newer than the given one.
+func (v RedmineVersion) AtLeast(major, minor int) bool {
+	return v.Major > major || v.Major == major && v.Minor >= minor
+}
+
+func (v RedmineVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// API features which depend on the version of Redmine.
+type Feature int
+
+const (
+	FeatureProjectActivities Feature = iota // projects include=time_entry_activities, 3.4
+	FeatureMyAccount                        // /my/account.json, 4.1
+	FeatureAllowedStatuses                  // issues include=allowed_statuses, 5.0
+)
+
+// The first Redmine versions supporting the features.
+var featureVersions = map[Feature]RedmineVersion{
+	FeatureProjectActivities: {3, 4, 0},
+	FeatureMyAccount:         {4, 1, 0},
+	FeatureAllowedStatuses:   {5, 0, 0},
+}
+
+// Guards the detected server versions of clients.
+var versionMu sync.Mutex
+
+// Get the version of Redmine server. Redmine API doesn't report its version, so it is
+// taken from ServerVersionHint if set, otherwise it is detected by the behavior of known
+// endpoints and is only a lower bound: 4.1 if /my/account.json is available, 0.0 otherwise.
+// The detected version is cached by client.
+func (ac *ApiClient) ServerVersion() (RedmineVersion, error) {
+	if ac.ServerVersionHint != "" {
+		return ParseRedmineVersion(ac.ServerVersionHint)
+	}
+
+	versionMu.Lock()
+	cached := ac.version
+	versionMu.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	// the lock is not held during the probe, so the slow server doesn't block
+	// the other clients, concurrent probes of the same client are harmless
+
+	u, err := BuildApiUrl(ac.Url, "/my/account.json", &url.Values{}, 0)
+	if err != nil {
+		return RedmineVersion{}, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	res, err := ac.do(http.MethodGet, u, nil)
+	if err != nil {
+		return RedmineVersion{}, err
+	}
+	res.Body.Close()
+
+	var v RedmineVersion
+	switch {
+	case res.StatusCode/100 == 2:
+		v = featureVersions[FeatureMyAccount]
+	case res.StatusCode != http.StatusNotFound:
+		return v, checkStatus(res)
+	}
+	versionMu.Lock()
+	ac.version = &v
+	versionMu.Unlock()
+	return v, nil
+}
+
+// Check whether the server supports the feature, e.g. to degrade gracefully on older
+// Redmine versions. The features of versions newer than the detected lower bound are
+// reported as unsupported unless ServerVersionHint is set.
+func (ac *ApiClient) Supports(f Feature) (bool, error) {
+	v, err := ac.ServerVersion()
+	if err != nil {
+		return false, err
+	}
+	since := featureVersions[f]
+	return v.AtLeast(since.Major, since.Minor), nil
+}
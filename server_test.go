@@ -0,0 +1,100 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRedmineVersion(t *testing.T) {
+	cases := map[string]RedmineVersion{
+		"5.1.2":       {5, 1, 2},
+		"4.2":         {4, 2, 0},
+		"4.2.stable":  {4, 2, 0},
+		"5.0.5.devel": {5, 0, 5},
+		" 3.4.13 ":    {3, 4, 13},
+	}
+	for s, expected := range cases {
+		v, err := ParseRedmineVersion(s)
+		if err != nil || v != expected {
+			t.Errorf("%q: expected %s, got: %s, %v", s, expected, v, err)
+		}
+	}
+	if _, err := ParseRedmineVersion("trunk"); !errors.Is(err, ServerVersionError) {
+		t.Errorf("expected ServerVersionError, got: %s", err)
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	var requests int
+	myAccount := true
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/my/account.json" || !myAccount {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"user": {"id": 1, "login": "jsmith"}}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	for range 2 {
+		v, err := ac.ServerVersion()
+		if err != nil || v != (RedmineVersion{4, 1, 0}) {
+			t.Errorf("expected 4.1.0, got: %s, %v", v, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the detected version to be cached, got %d requests", requests)
+	}
+	if ok, _ := ac.Supports(FeatureMyAccount); !ok {
+		t.Errorf("expected FeatureMyAccount to be supported")
+	}
+	if ok, _ := ac.Supports(FeatureAllowedStatuses); ok {
+		t.Errorf("expected FeatureAllowedStatuses to be unsupported")
+	}
+
+	myAccount = false
+	ac = CreateApiConfig(testServer.URL)
+	if ok, err := ac.Supports(FeatureProjectActivities); ok || err != nil {
+		t.Errorf("expected FeatureProjectActivities to be unsupported, got: %v", err)
+	}
+
+	requests = 0
+	ac.ServerVersionHint = "5.1.2"
+	if ok, err := ac.Supports(FeatureAllowedStatuses); !ok || err != nil {
+		t.Errorf("expected FeatureAllowedStatuses to be supported, got: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests with version hint, got: %d", requests)
+	}
+}
+
+func TestServerVersionSlowProbe(t *testing.T) {
+	release := make(chan struct{})
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slowServer.Close()
+	defer close(release)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer testServer.Close()
+
+	go CreateApiConfig(slowServer.URL).ServerVersion()
+	time.Sleep(50 * time.Millisecond) // let the probe of slow server start
+
+	done := make(chan struct{})
+	go func() {
+		CreateApiConfig(testServer.URL).ServerVersion()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("expected the probe of slow server not to block the other clients")
+	}
+}
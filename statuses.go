@@ -0,0 +1,43 @@
+package redmine
+
+import (
+	"errors"
+	"net/url"
+)
+
+const IssueStatusesEndpoint = "/issue_statuses.json"
+
+// A Redmine issue status, closed statuses count as "done".
+type IssueStatus struct {
+	Id       int      `json:"id"`
+	Name     string   `json:"name"`
+	IsClosed FlexBool `json:"is_closed"`
+}
+
+// Get all issue statuses, the endpoint is not paginated.
+func (ac *ApiClient) GetIssueStatuses() ([]IssueStatus, error) {
+	u, err := BuildApiUrl(ac.Url, IssueStatusesEndpoint, &url.Values{}, 0)
+	if err != nil {
+		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	statuses, err := GetOne[[]IssueStatus](ac, u, "issue_statuses")
+	if err != nil {
+		return nil, err
+	}
+	return *statuses, nil
+}
+
+// Get ids of closed issue statuses, they vary per Redmine instance, so don't hard-code them.
+func (ac *ApiClient) ClosedStatusIDs() ([]int, error) {
+	statuses, err := ac.GetIssueStatuses()
+	if err != nil {
+		return nil, err
+	}
+	ids := []int{}
+	for _, s := range statuses {
+		if s.IsClosed {
+			ids = append(ids, s.Id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,28 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestClosedStatusIDs(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"issue_statuses": [
+			{"id": 1, "name": "New", "is_closed": false},
+			{"id": 2, "name": "In Progress", "is_closed": false},
+			{"id": 5, "name": "Closed", "is_closed": true},
+			{"id": 6, "name": "Rejected", "is_closed": true}]}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ids, err := CreateApiConfig(testServer.URL).ClosedStatusIDs()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !slices.Equal(ids, []int{5, 6}) {
+		t.Errorf("expected [5 6], got: %v", ids)
+	}
+}
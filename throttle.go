@@ -0,0 +1,131 @@
+package redmine
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// State shared by client and its copies made internally, e.g. by batched scrolls.
+type clientState struct {
+	sem       chan struct{} // semaphore of MaxConcurrent, nil if not limited
+	requests  int64         // accessed atomically
+	limitZero int32         // support of limit=0 detected by Count, accessed atomically
+
+	paceMu sync.Mutex
+	next   time.Time // the earliest start of next request if MinInterval is set
+
+	lastMu sync.Mutex
+	last   ResponseInfo // the last response received by client or its copies
+}
+
+// Guards the lazy creation of shared states of clients.
+var stateMu sync.Mutex
+
+// Get the shared state of client, it must be created before copying of client to be shared.
+func (ac *ApiClient) shared() *clientState {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	if ac.state == nil {
+		ac.state = &clientState{}
+		if ac.MaxConcurrent > 0 {
+			ac.state.sem = make(chan struct{}, ac.MaxConcurrent)
+		}
+	}
+	return ac.state
+}
+
+// Copy the client to change its options (e.g. filter) for internal requests, the copy
+// shares the state (throttling, request count, last response) with client.
+func (ac *ApiClient) clone() *ApiClient {
+	ac.shared() // the state must exist before copying to be shared
+	c := *ac
+	return &c
+}
+
+// Copy the client with the given issues filter, see [ApiClient.clone].
+func (ac *ApiClient) withIssuesFilter(f IssuesFilter) *ApiClient {
+	c := ac.clone()
+	c.IssuesFilter = f
+	return c
+}
+
+// Copy the client with the given time entries filter, see [ApiClient.clone].
+func (ac *ApiClient) withTimeEntriesFilter(f TimeEntriesFilter) *ApiClient {
+	c := ac.clone()
+	c.TimeEntriesFilter = f
+	return c
+}
+
+// Get the semaphore of client, nil if the number of concurrent requests is not limited.
+func (ac *ApiClient) semaphore() chan struct{} {
+	return ac.shared().sem
+}
+
+func (ac *ApiClient) countRequest() {
+	atomic.AddInt64(&ac.shared().requests, 1)
+}
+
+// Get the total number of requests made by client, e.g. for quota tracking.
+func (ac *ApiClient) RequestCount() int64 {
+	return atomic.LoadInt64(&ac.shared().requests)
+}
+
+// Wait until the next request may start according to MinInterval, the slots are
+// reserved in order of calls, so the concurrent requests are spread evenly.
+func (ac *ApiClient) pace(ctx context.Context) error {
+	if ac.MinInterval <= 0 {
+		return nil
+	}
+	st := ac.shared()
+	st.paceMu.Lock()
+	start := time.Now()
+	if st.next.After(start) {
+		start = st.next
+	}
+	st.next = start.Add(ac.MinInterval)
+	st.paceMu.Unlock()
+
+	wait := time.Until(start)
+	if wait <= 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Acquire a slot of in-flight request, returns the function releasing it. The wait
+// for the slot is canceled along with ctx.
+func (ac *ApiClient) acquire(ctx context.Context) (func(), error) {
+	sem := ac.semaphore()
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Response body releasing the slot of in-flight request on close: the request is
+// in flight until its response is read.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	b.once.Do(b.release)
+	return b.ReadCloser.Close()
+}
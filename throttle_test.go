@@ -0,0 +1,136 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrent(t *testing.T) {
+	var inFlight, peak int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.MaxConcurrent = 2
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := GetOffset[Project](ac, 0); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("expected at most 2 requests in flight, got: %d", peak)
+	}
+	if len(ac.semaphore()) != 0 {
+		t.Errorf("expected all slots released, got: %d", len(ac.semaphore()))
+	}
+}
+
+func TestMaxConcurrentCanceled(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.MaxConcurrent = 1
+	// the slot is held until the body is closed
+	res, err := ac.GetWithContext(context.Background(), testServer.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer res.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := ac.GetWithContext(ctx, testServer.URL); !errors.Is(err, context.DeadlineExceeded) ||
+		!errors.Is(err, HttpError) {
+		t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+	}
+
+	dataChan, errChan := ScrollContext[Project](ctx, ac)
+	done := make(chan struct{})
+	go func() {
+		for range dataChan {
+		}
+		for range errChan {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("expected scroll to stop waiting for the slot on context deadline")
+	}
+}
+
+func TestMinInterval(t *testing.T) {
+	var mu sync.Mutex
+	var starts []time.Time
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+		w.Write([]byte(GenerateJSON(ProjectsJSONResponseTpl, GetResponseParamsFromUrl(r.URL.RawQuery))))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.MinInterval = 20 * time.Millisecond
+
+	begin := time.Now()
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := GetOffset[Project](ac, 0); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 4 requests need at least 3 intervals
+	if elapsed := time.Since(begin); elapsed < 3*ac.MinInterval {
+		t.Errorf("expected at least %s, got: %s", 3*ac.MinInterval, elapsed)
+	}
+	if len(starts) != 4 {
+		t.Errorf("expected 4 requests, got: %d", len(starts))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ac.MinInterval = time.Hour
+	ac.pace(context.Background()) // reserve the slot, so the next request has to wait
+	if _, err := ac.GetWithContext(ctx, testServer.URL); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Errors of payload validation, all of them are joined with [ValidationError].
+var (
+	ProjectAndIssueMissedError = errors.New("project or issue id must be set")
+	ZeroTimeDetectedError      = errors.New("spent on date is zero")
+	ZeroHoursError             = errors.New("hours must be greater than zero")
+	ImpersonationConflictError = errors.New("user id conflicts with switched user")
+)
+
+// Payload for creation of time entry, one of IssueID or ProjectID is required.
+type CreateTimeEntryPayload struct {
+	IssueID   int `json:"issue_id,omitempty"`
+	ProjectID int `json:"project_id,omitempty"`
+	// Zero date is omitted (Redmine defaults to today), see [CreateTimeEntryPayload.MarshalJSON].
+	SpentOn    Date    `json:"spent_on,omitempty"`
+	Hours      float32 `json:"hours"`
+	ActivityID int     `json:"activity_id,omitempty"`
+	Comments   string  `json:"comments,omitempty"`
+	UserID     int     `json:"user_id,omitempty"`
+}
+
+// Marshal the payload omitting zero date: omitempty has no effect on struct types,
+// so the zero date would be sent as "0001-01-01".
+func (p CreateTimeEntryPayload) MarshalJSON() ([]byte, error) {
+	type plain CreateTimeEntryPayload
+	aux := struct {
+		plain
+		SpentOn *Date `json:"spent_on,omitempty"`
+	}{plain: plain(p)}
+	if !p.SpentOn.IsZero() {
+		aux.SpentOn = &p.SpentOn
+	}
+	return json.Marshal(aux)
+}
+
+// JSON wrapper of time entry payload expected by Redmine: {"time_entry": {...}}.
+type PostTimeEntryParams struct {
+	TimeEntry CreateTimeEntryPayload `json:"time_entry"`
+}
+
+// Validate the time entry payload before sending it to Redmine.
+func (p CreateTimeEntryPayload) Validate() error {
+	return allErrors(
+		check(p.IssueID != 0 || p.ProjectID != 0, ProjectAndIssueMissedError, "issue_id, project_id"),
+		requireNonZeroDate(ZeroTimeDetectedError, "spent_on", p.SpentOn),
+		requirePositive(ZeroHoursError, "hours", p.Hours),
+	)
+}
+
+// Convert the time entry to payload for creation of the same time entry, e.g. to duplicate it
+// to another date: set the new SpentOn of returned payload.
+//
+// The time entry logged on issue is mapped only to IssueID, because Redmine takes
+// the project from the issue, otherwise the entry is mapped to ProjectID.
+func (t TimeEntry) ToPayload() CreateTimeEntryPayload {
+	p := CreateTimeEntryPayload{
+		SpentOn:    t.SpentOn,
+		Hours:      t.Hours,
+		ActivityID: t.Activity.Id,
+		Comments:   t.Comment,
+		UserID:     t.User.Id,
+	}
+	if t.Issue.Id != 0 {
+		p.IssueID = t.Issue.Id
+	} else {
+		p.ProjectID = t.Project.Id
+	}
+	return p
+}
+
+// Create time entry: validate the payload and send it to Redmine.
+//
+// To log time on behalf of another user, set either [ApiClient.SwitchUser] (the entry is
+// created as if the user did it, requires admin token) or UserID of payload (the entry
+// is created by the token owner for the user, requires "Log spent time for other users"
+// permission), preferably not both. If both are set and UserID is not the switched user,
+// Redmine behavior is ambiguous, so [ImpersonationConflictError] is returned and nothing
+// is created, this check costs an extra request to resolve the switched user.
+func (ac *ApiClient) CreateTimeEntry(p CreateTimeEntryPayload) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	if ac.SwitchUser != "" && p.UserID != 0 {
+		u, err := ac.CurrentUser()
+		if err != nil {
+			return err
+		}
+		if u.Id != p.UserID {
+			return errors.Join(ValidationError, ImpersonationConflictError,
+				fmt.Errorf("user id %d, switched user %s (id %d)", p.UserID, ac.SwitchUser, u.Id))
+		}
+	}
+
+	u, err := BuildApiUrl(ac.Url, TimeEntriesEndpoint, &url.Values{}, 0)
+	if err != nil {
+		return errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return CreateEntity(ac, u, "time_entry", p)
+}
+
+// Sum up hours of the filtered time entries (see [TimeEntriesFilter]) by activity name,
+// e.g. to audit which activities were actually used.
+func HoursByActivity(ac *ApiClient) (map[string]float32, error) {
+	entries, err := GetAll[TimeEntry](ac)
+	if err != nil {
+		return nil, err
+	}
+	hours := make(map[string]float32)
+	for _, t := range entries {
+		hours[t.Activity.Name] += t.Hours
+	}
+	return hours, nil
+}
+
+// Scroll time entries like [Scroll], but with the given filter instead of the filter of
+// client, e.g. to make reports for arbitrary date ranges without mutating the shared client.
+func ScrollTimeEntries(ac *ApiClient, f TimeEntriesFilter) (<-chan TimeEntry, <-chan error) {
+	return Scroll[TimeEntry](ac.withTimeEntriesFilter(f))
+}
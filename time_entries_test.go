@@ -0,0 +1,180 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeEntryToPayload(t *testing.T) {
+	spentOn := Date{time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	te := TimeEntry{
+		Id: 1, Project: Project{Id: 2}, Issue: Issue{Id: 3}, User: User{Id: 4},
+		Activity: NamedRef{Id: 9, Name: "Development"}, Hours: 1.5, Comment: "working", SpentOn: spentOn,
+	}
+
+	p := te.ToPayload()
+	expected := CreateTimeEntryPayload{
+		IssueID: 3, SpentOn: spentOn, Hours: 1.5, ActivityID: 9, Comments: "working", UserID: 4}
+	if p != expected {
+		t.Errorf("expected %+v, got: %+v", expected, p)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	b, err := json.Marshal(PostTimeEntryParams{p})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := `{"time_entry":{"issue_id":3,"hours":1.5,"activity_id":9,"comments":"working","user_id":4,"spent_on":"2024-03-01"}}`
+	if string(b) != s {
+		t.Errorf("expected %s, got: %s", s, b)
+	}
+
+	// zero date is omitted
+	p.SpentOn = Date{}
+	if b, _ = json.Marshal(PostTimeEntryParams{p}); strings.Contains(string(b), "spent_on") {
+		t.Errorf("expected omitted spent_on, got: %s", b)
+	}
+
+	// time entry of project without issue
+	te.Issue = Issue{}
+	if p = te.ToPayload(); p.ProjectID != 2 || p.IssueID != 0 {
+		t.Errorf("expected project 2 without issue, got: %+v", p)
+	}
+}
+
+func TestCreateTimeEntryPayloadValidate(t *testing.T) {
+	spentOn := Date{time.Now()}
+	cases := []struct {
+		payload  CreateTimeEntryPayload
+		expected error
+	}{
+		{CreateTimeEntryPayload{SpentOn: spentOn, Hours: 1}, ProjectAndIssueMissedError},
+		{CreateTimeEntryPayload{IssueID: 1, Hours: 1}, ZeroTimeDetectedError},
+		{CreateTimeEntryPayload{ProjectID: 1, SpentOn: spentOn}, ZeroHoursError},
+	}
+	for _, c := range cases {
+		err := c.payload.Validate()
+		if !errors.Is(err, ValidationError) || !errors.Is(err, c.expected) {
+			t.Errorf("expected %s, got: %s", c.expected, err)
+		}
+	}
+}
+
+func TestCreateTimeEntrySwitchUser(t *testing.T) {
+	var created int
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Redmine-Switch-User") != "jsmith" {
+			t.Errorf("expected switch user header, got: %v", r.Header)
+		}
+		switch r.URL.Path {
+		case CurrentUserEndpoint:
+			w.Write([]byte(`{"user": {"id": 2, "login": "jsmith"}}`))
+		case TimeEntriesEndpoint:
+			created++
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.SwitchUser = "jsmith"
+	p := CreateTimeEntryPayload{IssueID: 1, SpentOn: Today(), Hours: 1}
+
+	if err := ac.CreateTimeEntry(p); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	p.UserID = 2
+	if err := ac.CreateTimeEntry(p); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	p.UserID = 3
+	if err := ac.CreateTimeEntry(p); !errors.Is(err, ImpersonationConflictError) {
+		t.Errorf("expected ImpersonationConflictError, got: %s", err)
+	}
+	if created != 2 {
+		t.Errorf("expected 2 created time entries, got: %d", created)
+	}
+}
+
+func TestDecodeRawHours(t *testing.T) {
+	data := `{"time_entries": [
+		{"id": 1, "hours": 0.333333333333, "spent_on": "2024-03-01"},
+		{"id": 2, "hours": 7, "spent_on": "2024-03-01"}
+	], "offset": 0, "limit": 25, "total_count": 2}`
+
+	r, err := DecodeRespWith[TimeEntry](io.NopCloser(strings.NewReader(data)), DecodeOptions{UseNumber: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.Items[0].RawHours != "0.333333333333" || r.Items[1].RawHours != "7" {
+		t.Errorf("unexpected raw hours: %s, %s", r.Items[0].RawHours, r.Items[1].RawHours)
+	}
+	if r.Items[1].Hours != 7 {
+		t.Errorf("expected 7 hours, got: %.2f", r.Items[1].Hours)
+	}
+
+	r, _ = DecodeResp[TimeEntry](io.NopCloser(strings.NewReader(data)))
+	if r.Items[0].RawHours != "" {
+		t.Errorf("expected empty raw hours, got: %s", r.Items[0].RawHours)
+	}
+}
+
+func TestHoursByActivity(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"time_entries": [
+			{"id": 1, "hours": 1.5, "activity": {"id": 9, "name": "Development"}},
+			{"id": 2, "hours": 2, "activity": {"id": 10, "name": "Review"}},
+			{"id": 3, "hours": 0.5, "activity": {"id": 9, "name": "Development"}}
+		], "total_count": 3, "offset": 0, "limit": 25}`))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	hours, err := HoursByActivity(CreateApiConfig(testServer.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(hours) != 2 || hours["Development"] != 2 || hours["Review"] != 2 {
+		t.Errorf("unexpected hours: %v", hours)
+	}
+}
+
+func TestScrollTimeEntries(t *testing.T) {
+	var queries []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(`{"time_entries": [{"id": 1, "hours": 1}], "total_count": 1, "offset": 0, "limit": 25}`))
+	})
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	defaultFilter := ac.TimeEntriesFilter
+	f := TimeEntriesFilter{
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+		EndDate:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local),
+		UserId:    "7",
+	}
+	dataChan, _ := ScrollTimeEntries(ac, f)
+	n := 0
+	for range dataChan {
+		n++
+	}
+	if n != 1 || len(queries) != 1 || queries[0] != "from=2024-01-01&to=2024-01-31&user_id=7" {
+		t.Errorf("unexpected requests: %v", queries)
+	}
+	if ac.TimeEntriesFilter != defaultFilter {
+		t.Errorf("expected the filter of client untouched, got: %+v", ac.TimeEntriesFilter)
+	}
+}
@@ -0,0 +1,375 @@
+package redmine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var EmptyUpdateError = errors.New("no fields to update")
+
+// Builder of partial issue update: only the touched fields are sent, so the concurrent
+// changes of other fields made by someone else are not overwritten.
+//
+//	b := redmine.NewIssueUpdate().SetStatus(5).SetAssignee(3).AddNote("Done")
+//	err := ac.Issues().Update(42, b)
+type IssueUpdateBuilder struct {
+	fields       map[string]any
+	notes        []string
+	customFields []CustomField
+}
+
+// Create a new empty issue update.
+func NewIssueUpdate() *IssueUpdateBuilder {
+	return &IssueUpdateBuilder{fields: make(map[string]any)}
+}
+
+// Convert the payload to partial update touching only the set (non-zero) fields of it,
+// like [PutDataIssue]. The zero values can't be told from unset ones, so the clearing
+// of fields needs the setters of builder, except DoneRatio which is a pointer.
+func (p CreateIssuePayload) AsUpdate() *IssueUpdateBuilder {
+	b := NewIssueUpdate()
+	for field, id := range map[string]int{
+		"project_id":       p.ProjectID,
+		"tracker_id":       p.TrackerID,
+		"status_id":        p.StatusID,
+		"priority_id":      p.PriorityID,
+		"category_id":      p.CategoryID,
+		"fixed_version_id": p.FixedVersionID,
+		"assigned_to_id":   p.AssignedToID,
+		"parent_issue_id":  p.ParentID,
+	} {
+		if id != 0 {
+			b.set(field, id)
+		}
+	}
+	if p.Subject != "" {
+		b.SetSubject(p.Subject)
+	}
+	if p.Description != "" {
+		b.SetDescription(p.Description)
+	}
+	if len(p.Watchers) > 0 {
+		b.set("watcher_user_ids", p.Watchers)
+	}
+	if p.IsPrivate {
+		b.SetPrivate(true)
+	}
+	if p.EstimatedHours != 0 {
+		b.SetEstimatedHours(p.EstimatedHours)
+	}
+	if p.DoneRatio != nil {
+		b.SetDoneRatio(*p.DoneRatio)
+	}
+	if !p.StartDate.IsZero() {
+		b.SetStartDate(p.StartDate)
+	}
+	if !p.DueDate.IsZero() {
+		b.SetDueDate(p.DueDate)
+	}
+	for _, cf := range p.CustomFields {
+		b.SetCustomField(cf.ID, cf.Value)
+	}
+	return b
+}
+
+func (b *IssueUpdateBuilder) set(field string, v any) *IssueUpdateBuilder {
+	b.fields[field] = v
+	return b
+}
+
+func (b *IssueUpdateBuilder) SetStatus(id int) *IssueUpdateBuilder { return b.set("status_id", id) }
+
+// Set assignee, zero id unassigns the issue.
+func (b *IssueUpdateBuilder) SetAssignee(id int) *IssueUpdateBuilder {
+	if id == 0 {
+		return b.set("assigned_to_id", "")
+	}
+	return b.set("assigned_to_id", id)
+}
+
+func (b *IssueUpdateBuilder) SetSubject(s string) *IssueUpdateBuilder { return b.set("subject", s) }
+func (b *IssueUpdateBuilder) SetDoneRatio(r int) *IssueUpdateBuilder  { return b.set("done_ratio", r) }
+func (b *IssueUpdateBuilder) SetTracker(id int) *IssueUpdateBuilder   { return b.set("tracker_id", id) }
+func (b *IssueUpdateBuilder) SetPriority(id int) *IssueUpdateBuilder  { return b.set("priority_id", id) }
+func (b *IssueUpdateBuilder) SetPrivate(p bool) *IssueUpdateBuilder   { return b.set("is_private", p) }
+
+func (b *IssueUpdateBuilder) SetDescription(s string) *IssueUpdateBuilder {
+	return b.set("description", s)
+}
+
+// Set a reference field, zero id clears it.
+func (b *IssueUpdateBuilder) setRef(field string, id int) *IssueUpdateBuilder {
+	if id == 0 {
+		return b.set(field, "")
+	}
+	return b.set(field, id)
+}
+
+// Set target version, zero id clears it.
+func (b *IssueUpdateBuilder) SetFixedVersion(id int) *IssueUpdateBuilder {
+	return b.setRef("fixed_version_id", id)
+}
+
+// Set category, zero id clears it.
+func (b *IssueUpdateBuilder) SetCategory(id int) *IssueUpdateBuilder {
+	return b.setRef("category_id", id)
+}
+
+// Set parent issue, zero id makes the issue top-level.
+func (b *IssueUpdateBuilder) SetParent(id int) *IssueUpdateBuilder {
+	return b.setRef("parent_issue_id", id)
+}
+
+// Set a date field, zero date clears it.
+func (b *IssueUpdateBuilder) setDate(field string, d Date) *IssueUpdateBuilder {
+	if d.IsZero() {
+		return b.set(field, "")
+	}
+	return b.set(field, d)
+}
+
+// Set start date, zero date clears it.
+func (b *IssueUpdateBuilder) SetStartDate(d Date) *IssueUpdateBuilder {
+	return b.setDate("start_date", d)
+}
+
+// Set due date, zero date clears it.
+func (b *IssueUpdateBuilder) SetDueDate(d Date) *IssueUpdateBuilder {
+	return b.setDate("due_date", d)
+}
+
+// Set estimated hours, zero clears the estimation.
+func (b *IssueUpdateBuilder) SetEstimatedHours(h float32) *IssueUpdateBuilder {
+	if h == 0 {
+		return b.set("estimated_hours", "")
+	}
+	return b.set("estimated_hours", h)
+}
+
+// Add a note, the multiple notes are sent as one journal separated by blank lines.
+func (b *IssueUpdateBuilder) AddNote(text string) *IssueUpdateBuilder {
+	b.notes = append(b.notes, text)
+	return b
+}
+
+// Set value of custom field, the value is string or list of strings for multi-value fields.
+func (b *IssueUpdateBuilder) SetCustomField(id int, value any) *IssueUpdateBuilder {
+	for i := range b.customFields {
+		if b.customFields[i].ID == id {
+			b.customFields[i].Value = value
+			return b
+		}
+	}
+	b.customFields = append(b.customFields, CustomField{ID: id, Value: value})
+	return b
+}
+
+// Validate the touched fields of update, the update without any field is [EmptyUpdateError].
+func (b *IssueUpdateBuilder) Validate() error {
+	if len(b.fields) == 0 && len(b.notes) == 0 && len(b.customFields) == 0 {
+		return errors.Join(ValidationError, EmptyUpdateError)
+	}
+	var doneRatio error
+	if r, ok := b.fields["done_ratio"].(int); ok {
+		doneRatio = inRange(DoneRatioRangeError, "done_ratio", r, 0, 100)
+	}
+	start, _ := b.fields["start_date"].(Date)
+	due, _ := b.fields["due_date"].(Date)
+	return allErrors(
+		doneRatio,
+		check(start.IsZero() || due.IsZero() || !due.Before(start), DueDateError, "due_date"),
+	)
+}
+
+// Build the JSON payload {"issue": {...}} with only the touched fields.
+func (b *IssueUpdateBuilder) Build() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	issue := make(map[string]any, len(b.fields)+2)
+	for k, v := range b.fields {
+		issue[k] = v
+	}
+	if len(b.notes) > 0 {
+		issue["notes"] = strings.Join(b.notes, "\n\n")
+	}
+	if len(b.customFields) > 0 {
+		issue["custom_fields"] = b.customFields
+	}
+	data, err := json.Marshal(PostEnvelope[map[string]any]{"issue", issue})
+	if err != nil {
+		return nil, errors.Join(JsonEncodeError, err)
+	}
+	return data, nil
+}
+
+// Operations on issues.
+type IssuesService struct {
+	ac *ApiClient
+}
+
+func (ac *ApiClient) Issues() IssuesService {
+	return IssuesService{ac}
+}
+
+// Update issue with one PUT request containing only the fields touched by builder.
+func (s IssuesService) Update(id int, b *IssueUpdateBuilder) error {
+	data, err := b.Build()
+	if err != nil {
+		return err
+	}
+	return s.update(id, data)
+}
+
+func (s IssuesService) update(id int, data []byte) error {
+	u, err := BuildApiUrl(s.ac.Url, fmt.Sprintf("/issues/%d.json", id), &url.Values{}, 0)
+	if err != nil {
+		return errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return s.ac.Update(u, bytes.NewReader(data))
+}
+
+// Result of update of one issue in batch, Err is nil if the issue is updated.
+type BatchResult struct {
+	IssueID int
+	Err     error
+}
+
+// Apply the same partial update to all the issues matching the filter, e.g. to close all
+// issues of sprint. The matching issues are fetched first, so the updated issues don't
+// shift the pagination of filter. The failure of one issue (e.g. 422 of workflow) doesn't
+// abort the batch, it is reported in the result of issue, the returned error is the error
+// of fetching of issues or of building the payload.
+func (ac *ApiClient) BulkUpdateIssues(f IssuesFilter, b *IssueUpdateBuilder) ([]BatchResult, error) {
+	data, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := GetAll[Issue](ac.withIssuesFilter(f))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(issues))
+	for i, issue := range issues {
+		results[i] = BatchResult{issue.Id, ac.Issues().update(issue.Id, data)}
+	}
+	return results, nil
+}
+
+// Proposed change of issue field, the values are formatted as strings: ids for the
+// references (status_id, assigned_to_id), empty for unset.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Changes which the update would make to issue.
+type IssuePreview struct {
+	IssueID int
+	Changes []FieldChange
+}
+
+// Format the value of field for preview.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ", ")
+	case int:
+		if v == 0 {
+			return ""
+		}
+	case float32:
+		if v == 0 {
+			return ""
+		}
+	case Date:
+		if v.IsZero() {
+			return ""
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// Compute the changes the update would make to issue, the unchanged fields are skipped.
+func (b *IssueUpdateBuilder) changes(issue Issue) []FieldChange {
+	current := map[string]any{
+		"status_id":       issue.Status.Id,
+		"tracker_id":      issue.Tracker.Id,
+		"priority_id":     issue.Priority.Id,
+		"is_private":      bool(issue.IsPrivate),
+		"assigned_to_id":  issue.AssignedTo.Id,
+		"subject":         issue.Subject,
+		"description":     issue.Desc,
+		"done_ratio":      issue.DoneRatio,
+		"start_date":      issue.StartDate,
+		"due_date":        issue.DueDate,
+		"estimated_hours": issue.EstimatedHours,
+	}
+	if issue.FixedVersion != nil {
+		current["fixed_version_id"] = issue.FixedVersion.Id
+	}
+	if issue.Parent != nil {
+		current["parent_issue_id"] = issue.Parent.Id
+	}
+	if issue.Category != nil {
+		current["category_id"] = issue.Category.Id
+	}
+	var changes []FieldChange
+	for _, field := range slices.Sorted(maps.Keys(b.fields)) {
+		c := FieldChange{field, formatValue(current[field]), formatValue(b.fields[field])}
+		if field == "done_ratio" {
+			// zero is the valid value here
+			c.Old, c.New = strconv.Itoa(issue.DoneRatio), fmt.Sprint(b.fields[field])
+		}
+		if c.Old != c.New {
+			changes = append(changes, c)
+		}
+	}
+	for _, cf := range b.customFields {
+		var old string
+		if cur := issue.CustomField(cf.ID); cur != nil {
+			old = formatValue(cur.Value)
+		}
+		if c := (FieldChange{fmt.Sprintf("custom_field_%d", cf.ID), old, formatValue(cf.Value)}); c.Old != c.New {
+			changes = append(changes, c)
+		}
+	}
+	if len(b.notes) > 0 {
+		changes = append(changes, FieldChange{"notes", "", strings.Join(b.notes, "\n\n")})
+	}
+	return changes
+}
+
+// Preview the bulk update (see [ApiClient.BulkUpdateIssues]) without sending anything:
+// for every issue matching the filter return the proposed changes (current value -> new
+// value), the issues which the update would not change are skipped.
+func (ac *ApiClient) PreviewBulkUpdate(f IssuesFilter, b *IssueUpdateBuilder) ([]IssuePreview, error) {
+	if _, err := b.Build(); err != nil {
+		return nil, err
+	}
+
+	issues, err := GetAll[Issue](ac.withIssuesFilter(f))
+	if err != nil {
+		return nil, err
+	}
+
+	var previews []IssuePreview
+	for _, issue := range issues {
+		if changes := b.changes(issue); len(changes) > 0 {
+			previews = append(previews, IssuePreview{issue.Id, changes})
+		}
+	}
+	return previews, nil
+}
@@ -0,0 +1,196 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIssueUpdateBuilder(t *testing.T) {
+	data, err := NewIssueUpdate().
+		SetStatus(5).
+		SetAssignee(3).
+		AddNote("First").
+		AddNote("Second").
+		SetCustomField(2, "a").
+		SetCustomField(4, []string{"x", "y"}).
+		SetCustomField(2, "b").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got map[string]map[string]any
+	json.Unmarshal(data, &got)
+	expected := map[string]any{
+		"status_id":      5.0,
+		"assigned_to_id": 3.0,
+		"notes":          "First\n\nSecond",
+		"custom_fields": []any{
+			map[string]any{"id": 2.0, "value": "b"},
+			map[string]any{"id": 4.0, "value": []any{"x", "y"}},
+		},
+	}
+	if !reflect.DeepEqual(got["issue"], expected) {
+		t.Errorf("unexpected payload: %s", data)
+	}
+
+	data, _ = NewIssueUpdate().SetAssignee(0).Build()
+	if string(data) != `{"issue":{"assigned_to_id":""}}` {
+		t.Errorf("unexpected payload: %s", data)
+	}
+
+	if _, err := NewIssueUpdate().Build(); !errors.Is(err, EmptyUpdateError) {
+		t.Errorf("expected EmptyUpdateError, got: %s", err)
+	}
+}
+
+func TestIssuesUpdate(t *testing.T) {
+	var body string
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/issues/42.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	if err := ac.Issues().Update(42, NewIssueUpdate().SetDoneRatio(0)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if body != `{"issue":{"done_ratio":0}}` {
+		t.Errorf("unexpected payload: %s", body)
+	}
+	if err := ac.Issues().Update(43, NewIssueUpdate().SetSubject("x")); !errors.Is(err, NotFoundError) {
+		t.Errorf("expected NotFoundError, got: %s", err)
+	}
+}
+
+func TestBulkUpdateIssues(t *testing.T) {
+	var updated []string
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == IssuesApiEndpoint:
+			if r.URL.Query().Get("fixed_version_id") != "3" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"issues": [{"id": 1}, {"id": 2}, {"id": 3}],
+				"total_count": 3, "offset": 0, "limit": 25}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/issues/2.json":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors": ["Status is invalid"]}`))
+		case r.Method == http.MethodPut:
+			updated = append(updated, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	results, err := ac.BulkUpdateIssues(IssuesFilter{FixedVersionID: 3}, NewIssueUpdate().SetStatus(5))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 3 || results[0].Err != nil || results[2].Err != nil ||
+		results[1].IssueID != 2 || !errors.Is(results[1].Err, HttpError) {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if len(updated) != 2 {
+		t.Errorf("expected 2 updated issues, got: %v", updated)
+	}
+
+	if _, err := ac.BulkUpdateIssues(IssuesFilter{}, NewIssueUpdate()); !errors.Is(err, EmptyUpdateError) {
+		t.Errorf("expected EmptyUpdateError, got: %s", err)
+	}
+}
+
+func TestPreviewBulkUpdate(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			return
+		}
+		w.Write([]byte(`{"issues": [
+			{"id": 1, "status": {"id": 1, "name": "New"}, "assigned_to": {"id": 3, "name": "J. Smith"}, "done_ratio": 0},
+			{"id": 2, "status": {"id": 5, "name": "Closed"}, "done_ratio": 100},
+			{"id": 3, "status": {"id": 5, "name": "Closed"}, "assigned_to": {"id": 4, "name": "A. Brown"}, "done_ratio": 100}
+		], "total_count": 3, "offset": 0, "limit": 25}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	b := NewIssueUpdate().SetStatus(5).SetAssignee(4).SetDoneRatio(100)
+	previews, err := ac.PreviewBulkUpdate(IssuesFilter{}, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []IssuePreview{
+		{1, []FieldChange{{"assigned_to_id", "3", "4"}, {"done_ratio", "0", "100"}, {"status_id", "1", "5"}}},
+		{2, []FieldChange{{"assigned_to_id", "", "4"}}},
+	}
+	if !reflect.DeepEqual(previews, expected) {
+		t.Errorf("expected %+v, got: %+v", expected, previews)
+	}
+
+	previews, _ = ac.PreviewBulkUpdate(IssuesFilter{}, NewIssueUpdate().SetAssignee(0).AddNote("Bye"))
+	if len(previews) != 3 || !reflect.DeepEqual(previews[1].Changes, []FieldChange{{"notes", "", "Bye"}}) ||
+		!reflect.DeepEqual(previews[0].Changes[0], FieldChange{"assigned_to_id", "3", ""}) {
+		t.Errorf("unexpected previews: %+v", previews)
+	}
+}
+
+func TestPreviewNoopUpdate(t *testing.T) {
+	var issue Issue
+	data := `{"id": 1, "tracker": {"id": 2, "name": "Feature"}, "priority": {"id": 4, "name": "Urgent"},
+		"category": {"id": 7, "name": "UI"}, "is_private": false}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, b := range []*IssueUpdateBuilder{
+		NewIssueUpdate().SetTracker(2),
+		NewIssueUpdate().SetPriority(4),
+		NewIssueUpdate().SetCategory(7),
+		NewIssueUpdate().SetPrivate(false),
+	} {
+		if changes := b.changes(issue); len(changes) != 0 {
+			t.Errorf("expected no changes, got: %v", changes)
+		}
+	}
+
+	b := NewIssueUpdate().SetTracker(1).SetPriority(4).SetCategory(0).SetPrivate(true)
+	expected := []FieldChange{{"category_id", "7", ""}, {"is_private", "false", "true"}, {"tracker_id", "2", "1"}}
+	if changes := b.changes(issue); !slices.Equal(changes, expected) {
+		t.Errorf("expected %v, got: %v", expected, changes)
+	}
+}
+
+func TestIssueUpdateBuilderClearing(t *testing.T) {
+	due, _ := DateFromString("2024-03-31")
+	issue := Issue{Id: 1, DueDate: due, FixedVersion: &NamedRef{Id: 4}, EstimatedHours: 2}
+	b := NewIssueUpdate().SetDueDate(Date{}).SetFixedVersion(0).SetEstimatedHours(2).SetParent(0)
+	expected := []FieldChange{{"due_date", "2024-03-31", ""}, {"fixed_version_id", "4", ""}}
+	if changes := b.changes(issue); !slices.Equal(changes, expected) {
+		t.Errorf("expected %v, got: %v", expected, changes)
+	}
+
+	data, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := `{"issue":{"due_date":"","estimated_hours":2,"fixed_version_id":"","parent_issue_id":""}}`
+	if string(data) != s {
+		t.Errorf("expected %s, got: %s", s, data)
+	}
+}
@@ -0,0 +1,103 @@
+package redmine
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const CurrentUserEndpoint = "/users/current.json"
+
+// Associated data of user.
+const (
+	IncludeMemberships = "memberships"
+	IncludeGroups      = "groups"
+)
+
+// Status of user account.
+const (
+	UserStatusActive     = 1
+	UserStatusRegistered = 2
+	UserStatusLocked     = 3
+)
+
+// A page of users, requires admin privileges, see [UsersApiEndpoint].
+type Users = ApiResponse[User]
+
+// Construct the URL of users list page, e.g. for [Scroll] of users.
+func (ac *ApiClient) UsersUrl(page int) (string, error) {
+	u, err := ApiEndpointURL[User](ac, page)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get the user of API token, or the impersonated one if [ApiClient.SwitchUser] is set.
+func (ac *ApiClient) CurrentUser() (*User, error) {
+	u, err := BuildApiUrl(ac.Url, CurrentUserEndpoint, &url.Values{}, 0)
+	if err != nil {
+		return nil, errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return GetOne[User](ac, u, "user")
+}
+
+// Get the full name of user: first and last names, falling back to name (of user reference)
+// or login if they are empty.
+func (u User) FullName() string {
+	if name := strings.TrimSpace(u.Firstname + " " + u.Lastname); name != "" {
+		return name
+	}
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.Login
+}
+
+// Construct the URL of single user with optional associated data, e.g. include=memberships.
+func (ac *ApiClient) UserUrl(id int, include ...string) (string, error) {
+	v := url.Values{}
+	setInclude(&v, ac.includes("users", include...))
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/users/%d.json", id), &v, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get a single user by id, include is a list of associated data: memberships, groups.
+func (ac *ApiClient) GetUser(id int, include ...string) (*User, error) {
+	u, err := ac.UserUrl(id, include...)
+	if err != nil {
+		return nil, err
+	}
+	return GetOne[User](ac, u, "user")
+}
+
+// Get the projects user is a member of, deduplicated and sorted by name.
+func (ac *ApiClient) GetUserProjects(userID int) ([]Project, error) {
+	u, err := ac.GetUser(userID, IncludeMemberships)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]struct{})
+	projects := []Project{}
+	for _, m := range u.Memberships {
+		if _, ok := seen[m.Project.Id]; ok {
+			continue
+		}
+		seen[m.Project.Id] = struct{}{}
+		projects = append(projects, Project{Id: m.Project.Id, Name: m.Project.Name})
+	}
+	slices.SortFunc(projects, func(a, b Project) int { return cmp.Compare(a.Name, b.Name) })
+	return projects, nil
+}
+
+// Get the projects user is a member of, the same as [ApiClient.GetUserProjects].
+func (ac *ApiClient) UserProjects(userID int) ([]Project, error) {
+	return ac.GetUserProjects(userID)
+}
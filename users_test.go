@@ -0,0 +1,129 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetUserProjects(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include") != IncludeMemberships {
+			t.Errorf("expected include=memberships, got: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/users/1.json":
+			w.Write([]byte(`{"user": {"id": 1, "login": "jsmith", "memberships": [
+				{"id": 1, "project": {"id": 2, "name": "Zeta"}, "roles": [{"id": 3, "name": "Manager"}]},
+				{"id": 2, "project": {"id": 1, "name": "Alpha"}, "roles": [{"id": 4, "name": "Developer"}]},
+				{"id": 3, "project": {"id": 2, "name": "Zeta"}, "roles": [{"id": 4, "name": "Developer"}]}]}}`))
+		case "/users/2.json":
+			w.Write([]byte(`{"user": {"id": 2, "login": "nobody"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	projects, err := ac.GetUserProjects(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(projects) != 2 || projects[0].Name != "Alpha" || projects[1].Name != "Zeta" {
+		t.Errorf("expected [Alpha Zeta], got: %v", projects)
+	}
+
+	projects, err = ac.GetUserProjects(2)
+	if err != nil || projects == nil || len(projects) != 0 {
+		t.Errorf("expected empty list, got: %v, %v", projects, err)
+	}
+
+	projects, err = ac.UserProjects(1)
+	if err != nil || len(projects) != 2 {
+		t.Errorf("expected 2 projects, got: %v, %v", projects, err)
+	}
+}
+
+func TestUserFullName(t *testing.T) {
+	cases := []struct {
+		user     User
+		expected string
+	}{
+		{User{Firstname: "John", Lastname: "Smith", Name: "J. Smith", Login: "jsmith"}, "John Smith"},
+		{User{Firstname: "John", Login: "jsmith"}, "John"},
+		{User{Lastname: "Smith", Login: "jsmith"}, "Smith"},
+		{User{Name: "J. Smith", Login: "jsmith"}, "J. Smith"},
+		{User{Login: "jsmith"}, "jsmith"},
+		{User{}, ""},
+	}
+	for _, c := range cases {
+		if name := c.user.FullName(); name != c.expected {
+			t.Errorf("%+v: expected %q, got: %q", c.user, c.expected, name)
+		}
+	}
+}
+
+func TestScrollUsers(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != UsersApiEndpoint {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("offset") == "2" {
+			w.Write([]byte(`{"users": [{"id": 3, "login": "locked", "status": 3}],
+				"total_count": 3, "offset": 2, "limit": 2}`))
+			return
+		}
+		w.Write([]byte(`{"users": [
+			{"id": 1, "login": "admin", "firstname": "Redmine", "lastname": "Admin", "mail": "admin@example.net", "status": 1},
+			{"id": 2, "login": "jsmith", "mail": "jsmith@somenet.foo", "status": 1}],
+			"total_count": 3, "offset": 0, "limit": 2}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	dataChan, _ := Scroll[User](CreateApiConfig(testServer.URL))
+	var users []User
+	for u := range dataChan {
+		users = append(users, u)
+	}
+	if len(users) != 3 {
+		t.Fatalf("expected 3 users, got: %v", users)
+	}
+	if users[0].Login != "admin" || users[0].Mail != "admin@example.net" || users[0].Status != UserStatusActive {
+		t.Errorf("unexpected first user: %+v", users[0])
+	}
+	if users[2].Status != UserStatusLocked {
+		t.Errorf("expected locked user, got: %+v", users[2])
+	}
+}
+
+func TestUsersUrl(t *testing.T) {
+	ac := CreateApiConfig("http://example.com")
+	u, err := ac.UsersUrl(2)
+	if err != nil || u != "http://example.com/users.json?page=2" {
+		t.Errorf("unexpected url: %s, %v", u, err)
+	}
+}
+
+func TestGetUsers(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"users": [{"id": 5, "login": "jdoe", "firstname": "John", "lastname": "Doe",
+			"mail": "jdoe@example.net", "status": 2}], "total_count": 1, "offset": 0, "limit": 25}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	r, err := Get[User](CreateApiConfig(testServer.URL), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var users Users = *r
+	want := User{Id: 5, Login: "jdoe", Firstname: "John", Lastname: "Doe", Mail: "jdoe@example.net",
+		Status: UserStatusRegistered}
+	if len(users.Items) != 1 || users.Total != 1 || users.Items[0].FullName() != "John Doe" ||
+		users.Items[0].Mail != want.Mail || users.Items[0].Status != want.Status || users.Items[0].Login != want.Login {
+		t.Errorf("expected %+v, got: %+v", want, users)
+	}
+}
@@ -0,0 +1,51 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The common error of payload validation, every specific validation error is joined with it.
+var ValidationError = errors.New("validation error")
+
+// Shared validation helpers of payloads: return nil if the check passes, otherwise
+// the specific sentinel error joined with [ValidationError] and the field name.
+
+func check(ok bool, sentinel error, field string) error {
+	if ok {
+		return nil
+	}
+	return errors.Join(ValidationError, sentinel, fmt.Errorf("invalid field: %s", field))
+}
+
+func requireNonZeroInt(sentinel error, field string, val int) error {
+	return check(val != 0, sentinel, field)
+}
+
+func requirePositive[T int | float32 | float64](sentinel error, field string, val T) error {
+	return check(val > 0, sentinel, field)
+}
+
+func requireNonEmpty(sentinel error, field, s string) error {
+	return check(strings.TrimSpace(s) != "", sentinel, field)
+}
+
+func requireNonZeroDate(sentinel error, field string, d Date) error {
+	return check(!d.IsZero(), sentinel, field)
+}
+
+func inRange(sentinel error, field string, val, lo, hi int) error {
+	return check(val >= lo && val <= hi, sentinel, field)
+}
+
+func oneOf[T comparable](sentinel error, field string, val T, allowed ...T) error {
+	return check(slices.Contains(allowed, val), sentinel, field)
+}
+
+// Join all the failed checks, so every violated rule is reported at once and each one
+// is reachable with errors.Is, nil if all the checks pass.
+func allErrors(errs ...error) error {
+	return errors.Join(errs...)
+}
@@ -0,0 +1,60 @@
+package redmine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationHelpers(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	cases := []struct {
+		err   error
+		valid bool
+	}{
+		{requireNonZeroInt(sentinel, "id", 1), true},
+		{requireNonZeroInt(sentinel, "id", 0), false},
+		{requirePositive(sentinel, "hours", float32(0.5)), true},
+		{requirePositive(sentinel, "hours", float32(-1)), false},
+		{requireNonEmpty(sentinel, "subject", "subj"), true},
+		{requireNonEmpty(sentinel, "subject", "  "), false},
+		{requireNonZeroDate(sentinel, "spent_on", Today()), true},
+		{requireNonZeroDate(sentinel, "spent_on", Date{}), false},
+		{inRange(sentinel, "done_ratio", 100, 0, 100), true},
+		{inRange(sentinel, "done_ratio", 101, 0, 100), false},
+		{oneOf(sentinel, "status", "open", "open", "locked", "closed"), true},
+		{oneOf(sentinel, "status", "done", "open", "locked", "closed"), false},
+	}
+	for i, c := range cases {
+		if c.valid && c.err != nil {
+			t.Errorf("%d: unexpected error: %s", i, c.err)
+		}
+		if !c.valid && (!errors.Is(c.err, ValidationError) || !errors.Is(c.err, sentinel)) {
+			t.Errorf("%d: expected validation error, got: %v", i, c.err)
+		}
+	}
+
+	if err := allErrors(nil, cases[1].err, cases[3].err); !errors.Is(err, cases[1].err) || !errors.Is(err, cases[3].err) {
+		t.Errorf("expected both errors, got: %s", err)
+	}
+	if err := allErrors(nil, cases[0].err); err != nil {
+		t.Errorf("expected no error, got: %s", err)
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	err := CreateTimeEntryPayload{Hours: 1}.Validate()
+	if !errors.Is(err, ProjectAndIssueMissedError) || !errors.Is(err, ZeroTimeDetectedError) {
+		t.Errorf("expected both missed project and zero date, got: %v", err)
+	}
+	if errors.Is(err, ZeroHoursError) {
+		t.Errorf("unexpected ZeroHoursError: %v", err)
+	}
+
+	ratio := 150
+	err = CreateIssuePayload{DoneRatio: &ratio, Watchers: []int{0}}.Validate()
+	for _, want := range []error{ValidationError, EmptyProjectError, DoneRatioRangeError, WatcherIDError} {
+		if !errors.Is(err, want) {
+			t.Errorf("expected %q, got: %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// Statuses of version.
+const (
+	VersionStatusOpen   = "open"
+	VersionStatusLocked = "locked"
+	VersionStatusClosed = "closed"
+)
+
+// Unknown status of version, it is joined with [ValidationError].
+var VersionStatusError = errors.New("version status must be one of: open, locked, closed")
+
+// A Redmine version (a.k.a. fix version, milestone).
+type Version struct {
+	Id          int      `json:"id"`
+	Project     NamedRef `json:"project"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	DueDate     *Date    `json:"due_date"` // nil if not set
+	Sharing     string   `json:"sharing"`
+}
+
+// Construct the URL of project versions.
+func (ac *ApiClient) VersionsUrl(projectID int) (string, error) {
+	u, err := BuildApiUrl(ac.Url, fmt.Sprintf("/projects/%d/versions.json", projectID), &url.Values{}, 0)
+	if err != nil {
+		return "", errors.Join(ApiEndpointUrlFatalError, err)
+	}
+	return u, nil
+}
+
+// Get the versions of project (including shared ones), filtered by statuses if any given,
+// e.g. VersionStatusOpen. The endpoint is not paginated and returns all the versions,
+// so the filtration is done on client side. The unknown statuses are rejected with
+// [VersionStatusError] rather than silently matching nothing.
+func (ac *ApiClient) GetVersions(projectID int, statuses ...string) ([]Version, error) {
+	var errs []error
+	for _, s := range statuses {
+		errs = append(errs, oneOf(VersionStatusError, "status", s,
+			VersionStatusOpen, VersionStatusLocked, VersionStatusClosed))
+	}
+	if err := allErrors(errs...); err != nil {
+		return nil, err
+	}
+
+	u, err := ac.VersionsUrl(projectID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := GetOne[[]Version](ac, u, "versions")
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return *all, nil
+	}
+
+	versions := []Version{}
+	for _, v := range *all {
+		if slices.Contains(statuses, v.Status) {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// Get the open versions of project, e.g. for "fix version" dropdown.
+func (ac *ApiClient) OpenVersions(projectID int) ([]Version, error) {
+	return ac.GetVersions(projectID, VersionStatusOpen)
+}
+
+// Compute the workload of version for release planning: the total estimated and spent
+// hours of all (open and closed) issues of the version, the missing estimates count as zero.
+// The hours of issues are summed without subtasks rollup, so parent issues don't count
+// the hours of their children twice.
+func (ac *ApiClient) VersionWorkload(projectID, versionID int) (estimated, spent float32, err error) {
+	f := IssuesFilter{ProjectID: projectID, FixedVersionID: versionID, StatusID: "*"}
+	issues, err := GetAll[Issue](ac.withIssuesFilter(f))
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, i := range issues {
+		estimated += i.EstimatedHours
+		spent += i.SpentHours
+	}
+	return estimated, spent, nil
+}
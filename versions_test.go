@@ -0,0 +1,75 @@
+package redmine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenVersions(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/1/versions.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"versions": [
+			{"id": 1, "project": {"id": 1, "name": "Project1"}, "name": "1.0", "status": "closed", "due_date": "2024-01-31"},
+			{"id": 2, "project": {"id": 1, "name": "Project1"}, "name": "1.1", "status": "locked", "due_date": null},
+			{"id": 3, "project": {"id": 1, "name": "Project1"}, "name": "2.0", "status": "open", "due_date": "2024-06-30"},
+			{"id": 4, "project": {"id": 2, "name": "Shared"}, "name": "2.1", "status": "open", "sharing": "system"}
+		], "total_count": 4}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	versions, err := ac.OpenVersions(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(versions) != 2 || versions[0].Id != 3 || versions[1].Id != 4 {
+		t.Errorf("expected open versions [3 4], got: %+v", versions)
+	}
+	if versions[0].DueDate == nil || versions[0].DueDate.String() != "2024-06-30" {
+		t.Errorf("unexpected due date: %v", versions[0].DueDate)
+	}
+
+	if versions, _ = ac.GetVersions(1); len(versions) != 4 {
+		t.Errorf("expected all 4 versions, got: %d", len(versions))
+	}
+	if versions, _ = ac.GetVersions(1, VersionStatusLocked, VersionStatusClosed); len(versions) != 2 {
+		t.Errorf("expected 2 locked or closed versions, got: %d", len(versions))
+	}
+	_, err = ac.GetVersions(1, VersionStatusOpen, "opened")
+	if !errors.Is(err, VersionStatusError) || !errors.Is(err, ValidationError) {
+		t.Errorf("expected VersionStatusError, got: %v", err)
+	}
+}
+
+func TestVersionWorkload(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != IssuesApiEndpoint || q.Get("project_id") != "1" ||
+			q.Get("fixed_version_id") != "3" || q.Get("status_id") != "*" {
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"issues": [
+			{"id": 1, "fixed_version": {"id": 3, "name": "1.0"}, "estimated_hours": 4, "spent_hours": 5.5},
+			{"id": 2, "fixed_version": {"id": 3, "name": "1.0"}, "estimated_hours": null, "spent_hours": 1},
+			{"id": 3, "fixed_version": {"id": 3, "name": "1.0"}, "estimated_hours": 2.5}
+		], "total_count": 3, "offset": 0, "limit": 25}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	estimated, spent, err := CreateApiConfig(testServer.URL).VersionWorkload(1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if estimated != 6.5 || spent != 6.5 {
+		t.Errorf("expected 6.5/6.5 hours, got: %v/%v", estimated, spent)
+	}
+}
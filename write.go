@@ -0,0 +1,234 @@
+package redmine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Send POST request with JSON payload to Redmine API, returns the status code and the body
+// of response, the caller is responsible for closing of body.
+func (ac *ApiClient) Post(uri string, data io.Reader) (int, io.ReadCloser, error) {
+	return ac.PostContent(uri, "application/json", data)
+}
+
+// Send POST request like [ApiClient.Post], but with payload of the given content type,
+// e.g. application/octet-stream for /uploads.json or form-encoded for some plugins.
+func (ac *ApiClient) PostContent(uri, contentType string, data io.Reader) (int, io.ReadCloser, error) {
+	res, err := ac.doContent(http.MethodPost, uri, contentType, data)
+	if err != nil {
+		return 0, nil, err
+	}
+	return res.StatusCode, res.Body, nil
+}
+
+// Send POST request with JSON payload like [ApiClient.Post], canceled along with ctx,
+// e.g. when the request of server handler embedding the client is done.
+func (ac *ApiClient) PostWithContext(ctx context.Context, uri string, data io.Reader) (int, io.ReadCloser, error) {
+	res, err := ac.doContext(ctx, http.MethodPost, uri, "application/json", data)
+	if err != nil {
+		return 0, nil, err
+	}
+	return res.StatusCode, res.Body, nil
+}
+
+// Create Redmine entity: send POST request and expect 201 Created status code,
+// otherwise return [HttpError] with errors reported by Redmine.
+func (ac *ApiClient) Create(uri string, data io.Reader) error {
+	_, err := ac.CreateLocated(uri, data)
+	return err
+}
+
+// Create Redmine entity like [ApiClient.Create] and return the URL of created entity from
+// Location header, it is a lightweight way to learn the new id without decoding
+// the body, see [LocationID].
+func (ac *ApiClient) CreateLocated(uri string, data io.Reader) (string, error) {
+	res, err := ac.do(http.MethodPost, uri, data)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return "", responseError(res)
+	}
+	return res.Header.Get("Location"), nil
+}
+
+// Parse the id of created entity from the Location URL, e.g. 42 of
+// https://redmine.example.com/issues/42 or /issues/42.json.
+func LocationID(location string) (int, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return 0, errors.Join(UrlParseError, err)
+	}
+	last := path.Base(u.Path)
+	id, err := strconv.Atoi(strings.TrimSuffix(last, path.Ext(last)))
+	if err != nil {
+		return 0, fmt.Errorf("no id in location %q: %w", location, err)
+	}
+	return id, nil
+}
+
+// Send idempotent (PUT, DELETE) request retrying the transient failures (network errors,
+// 429 and 5xx responses) according to the retry policy of client, the payload is buffered
+// to be resent. The response of the last attempt is returned as is.
+func (ac *ApiClient) doIdempotent(method, uri string, data io.Reader) (*http.Response, error) {
+	var payload []byte
+	if data != nil {
+		var err error
+		if payload, err = io.ReadAll(data); err != nil {
+			return nil, errors.Join(IoReadError, err)
+		}
+	}
+	rp := ac.retryPolicy()
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(payload)
+		}
+		res, err := ac.do(method, uri, body)
+		failure := err
+		if err == nil {
+			if res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+				return res, nil
+			}
+			failure = checkStatus(res)
+		}
+		if !retryable(failure) || !rp.Allow(attempt) {
+			return res, err
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+		delay := max(rp.Delay(attempt), RetryAfter(failure))
+		ac.logRetry(attempt, delay, failure)
+		time.Sleep(delay)
+	}
+}
+
+// Send PUT request with JSON payload to Redmine API, returns the status code and the body
+// of response, the caller is responsible for closing of body. The transient failures
+// are retried, see [RetryPolicy].
+func (ac *ApiClient) Put(uri string, data io.Reader) (int, io.ReadCloser, error) {
+	res, err := ac.doIdempotent(http.MethodPut, uri, data)
+	if err != nil {
+		return 0, nil, err
+	}
+	return res.StatusCode, res.Body, nil
+}
+
+// Update Redmine entity: send PUT request and expect 204 No Content (or 200 OK) status code,
+// otherwise return [HttpError] with errors reported by Redmine. The transient failures
+// are retried, see [RetryPolicy].
+func (ac *ApiClient) Update(uri string, data io.Reader) error {
+	res, err := ac.doIdempotent(http.MethodPut, uri, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return responseError(res)
+	}
+	return nil
+}
+
+// Payload which can be validated before sending it to Redmine.
+type Validator interface {
+	Validate() error
+}
+
+// Create Redmine entity from payload wrapped under the root key, e.g. "issue": the payload
+// is always validated first and the validation error is returned without any request.
+func CreateEntity[P Validator](ac *ApiClient, uri, key string, payload P) error {
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(PostEnvelope[P]{key, payload})
+	if err != nil {
+		return errors.Join(JsonEncodeError, err)
+	}
+	return ac.Create(uri, bytes.NewReader(data))
+}
+
+// JSON wrapper of payload under the given root key: {"<key>": payload}, e.g. for plugin
+// endpoints which use non-standard root key, see also [PostDataIssue], [PostTimeEntryParams].
+type PostEnvelope[T any] struct {
+	Key     string
+	Payload T
+}
+
+func (e PostEnvelope[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]T{e.Key: e.Payload})
+}
+
+// Build error of failed write request. Redmine reports the validation failures
+// as JSON {"errors": [...]}, but only application/json body is parsed as JSON,
+// anything else (e.g. HTML error page of proxy) is returned as raw text, so the parse
+// failure does not mask the real error message.
+func responseError(res *http.Response) error {
+	if apiDisabled(res) {
+		return apiDisabledError(res)
+	}
+	statusErr := errors.Join(HttpError, newStatusError(res))
+	if isAuthFailure(res) {
+		statusErr = errors.Join(statusErr, AuthError)
+	}
+	if res.StatusCode == http.StatusNotFound {
+		statusErr = errors.Join(statusErr, NotFoundError)
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		statusErr = rateLimitedError(res)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Join(statusErr, IoReadError, err)
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return statusErr
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		var body struct {
+			Errors []string `json:"errors"`
+		}
+		if err := json.Unmarshal(data, &body); err == nil && len(body.Errors) > 0 {
+			errs := []error{statusErr}
+			for _, e := range body.Errors {
+				errs = append(errs, errors.New(e))
+			}
+			return errors.Join(errs...)
+		}
+	}
+	return errors.Join(statusErr, errors.New(text))
+}
+
+// Send DELETE request to Redmine API, returns the status code of response, anything
+// except 200 OK and 204 No Content is [HttpError] (404 is also [NotFoundError]).
+// The transient failures are retried, see [RetryPolicy].
+func (ac *ApiClient) Delete(uri string) (int, error) {
+	res, err := ac.doIdempotent(http.MethodDelete, uri, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return res.StatusCode, responseError(res)
+	}
+	return res.StatusCode, nil
+}
@@ -0,0 +1,200 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreate(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got: %s", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got: %s", ct)
+		}
+		switch r.URL.Path {
+		case "/ok.json":
+			w.WriteHeader(http.StatusCreated)
+		case "/json.json":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors": ["Subject cannot be blank", "Tracker is not included in the list"]}`))
+		case "/html.json":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`<html><body>502 Bad Gateway</body></html>`))
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	body := func() io.Reader { return strings.NewReader(`{}`) }
+
+	if err := ac.Create(testServer.URL+"/ok.json", body()); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err := ac.Create(testServer.URL+"/json.json", body())
+	if !errors.Is(err, HttpError) {
+		t.Errorf("expected HttpError, got: %s", err)
+	}
+	for _, msg := range []string{"Subject cannot be blank", "Tracker is not included in the list"} {
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected %q in error, got: %s", msg, err)
+		}
+	}
+
+	err = ac.Create(testServer.URL+"/html.json", body())
+	if !errors.Is(err, HttpError) {
+		t.Errorf("expected HttpError, got: %s", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "502 Bad Gateway</body>") || errors.Is(err, JsonDecodeError) {
+		t.Errorf("expected raw HTML in error, got: %s", err)
+	}
+}
+
+func TestCreateLocated(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://redmine.example.com/issues/42")
+		w.WriteHeader(http.StatusCreated)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	loc, err := CreateApiConfig(testServer.URL).CreateLocated(testServer.URL+IssuesApiEndpoint, strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if loc != "https://redmine.example.com/issues/42" {
+		t.Errorf("unexpected location: %s", loc)
+	}
+
+	cases := map[string]int{loc: 42, "/issues/42.json": 42, "/time_entries/7": 7}
+	for loc, expected := range cases {
+		if id, err := LocationID(loc); err != nil || id != expected {
+			t.Errorf("%s: expected %d, got: %d (%v)", loc, expected, id, err)
+		}
+	}
+	if _, err := LocationID("/issues/new"); err == nil {
+		t.Error("expected error for location without id")
+	}
+}
+
+func TestPostEnvelope(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name"`
+		Votes int    `json:"votes,omitempty"`
+	}
+	b, err := json.Marshal(PostEnvelope[payload]{"poll", payload{Name: "lunch"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(b) != `{"poll":{"name":"lunch"}}` {
+		t.Errorf("unexpected JSON: %s", b)
+	}
+
+	p := CreateTimeEntryPayload{IssueID: 1, Hours: 1.5}
+	b1, _ := json.Marshal(PostEnvelope[CreateTimeEntryPayload]{"time_entry", p})
+	b2, _ := json.Marshal(PostTimeEntryParams{p})
+	if string(b1) != string(b2) {
+		t.Errorf("expected %s, got: %s", b2, b1)
+	}
+}
+
+func TestCreateEntityValidatesFirst(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusCreated)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	err := ac.CreateTimeEntry(CreateTimeEntryPayload{SpentOn: Today(), Hours: 1})
+	if !errors.Is(err, ProjectAndIssueMissedError) {
+		t.Errorf("expected ProjectAndIssueMissedError, got: %s", err)
+	}
+	if err = ac.CreateIssue(CreateIssuePayload{Subject: "subj"}); !errors.Is(err, EmptyProjectError) {
+		t.Errorf("expected EmptyProjectError, got: %s", err)
+	}
+}
+
+func TestPostWithContext(t *testing.T) {
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow.json" {
+			io.ReadAll(r.Body) // the disconnect is detected only after the body is read
+			<-r.Context().Done()
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got: %s", ct)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"issue": {"id": 1}}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+	ac := CreateApiConfig(testServer.URL)
+
+	status, body, err := ac.PostWithContext(context.Background(), testServer.URL+"/issues.json", strings.NewReader(`{}`))
+	if err != nil || status != http.StatusCreated {
+		t.Fatalf("unexpected result: %d, %v", status, err)
+	}
+	body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = ac.PostWithContext(ctx, testServer.URL+"/slow.json", strings.NewReader(`{}`))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
+func TestWriteRetries(t *testing.T) {
+	var requests []string
+	status := http.StatusServiceUnavailable
+	handleReq := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+string(b))
+		if len(requests)%2 == 1 {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handleReq))
+	defer testServer.Close()
+
+	ac := CreateApiConfig(testServer.URL)
+	ac.Retry = RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+	if err := ac.Update(testServer.URL, strings.NewReader(`{"issue":{}}`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	status = http.StatusTooManyRequests
+	if _, err := ac.Delete(testServer.URL); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []string{`PUT {"issue":{}}`, `PUT {"issue":{}}`, "DELETE ", "DELETE "}
+	if strings.Join(requests, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got: %q", expected, requests)
+	}
+
+	// the other 4xx are not retried, POST is never retried
+	requests, status = nil, http.StatusUnprocessableEntity
+	if err := ac.Update(testServer.URL, strings.NewReader("{}")); !errors.Is(err, HttpError) || len(requests) != 1 {
+		t.Errorf("expected single failed request, got: %v, %q", err, requests)
+	}
+	requests, status = nil, http.StatusServiceUnavailable
+	if err := ac.Create(testServer.URL, strings.NewReader("{}")); !errors.Is(err, HttpError) || len(requests) != 1 {
+		t.Errorf("expected single failed request, got: %v, %q", err, requests)
+	}
+}